@@ -10,6 +10,7 @@ replace (
 
 require (
 	github.com/go-openapi/runtime v0.19.11
+	github.com/go-openapi/strfmt v0.19.3
 	github.com/go-openapi/validate v0.19.5 // indirect
 	github.com/google/go-cmp v0.3.1
 	github.com/hashicorp/go-version v1.2.0
@@ -21,4 +22,5 @@ require (
 	go.uber.org/multierr v1.1.0 // indirect
 	go.uber.org/zap v1.9.1
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
+	gopkg.in/yaml.v2 v2.2.4
 )
@@ -0,0 +1,258 @@
+package kubermatic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-openapi/runtime"
+	oclient "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
+	k8client "github.com/kubermatic/go-kubermatic/client"
+	"github.com/kubermatic/go-kubermatic/client/serviceaccounts"
+	"github.com/kubermatic/go-kubermatic/client/tokens"
+	"github.com/kubermatic/go-kubermatic/models"
+	"go.uber.org/zap"
+)
+
+// refresh token if it expires earlier than this
+const tokenRefreshWindow = 10 * time.Minute
+
+// tokenAuth authenticates requests with a bearer token. The token can
+// be regenerated while the provider runs, so long applies survive the
+// expiry of the configured token.
+type tokenAuth struct {
+	mu    sync.Mutex
+	token string
+	// refresh returns a new token for the given one, nil disables refreshing
+	refresh func(token string) (string, error)
+	// path is the token file, regenerating revokes the previous token so
+	// the new one is written back to it
+	path string
+	log  *zap.SugaredLogger
+}
+
+func (a *tokenAuth) AuthenticateRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
+	return oclient.BearerToken(a.currentToken()).AuthenticateRequest(r, reg)
+}
+
+func (a *tokenAuth) currentToken() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.refresh == nil || !tokenExpiresWithin(a.token, tokenRefreshWindow) {
+		return a.token
+	}
+
+	token, err := a.refresh(a.token)
+	if err != nil {
+		// don't retry on every request, refreshing is most likely not permitted
+		a.log.Warnf("unable to refresh expiring token, token refresh disabled: %v", err)
+		a.refresh = nil
+		return a.token
+	}
+	a.replace(token, "token is about to expire")
+	return a.token
+}
+
+// reauthenticate regenerates the token after it was rejected. The token is
+// not regenerated again if another request already replaced it.
+func (a *tokenAuth) reauthenticate(rejected string) (string, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != rejected {
+		return a.token, nil
+	}
+	if a.refresh == nil {
+		return "", fmt.Errorf("token refresh is not enabled")
+	}
+
+	token, err := a.refresh(a.token)
+	if err != nil {
+		return "", err
+	}
+	a.replace(token, "token has been rejected")
+	return a.token, nil
+}
+
+// replace switches to the regenerated token and saves it to the token
+// file, the previous token is revoked at this point.
+func (a *tokenAuth) replace(token, reason string) {
+	a.token = token
+	if a.path == "" {
+		a.log.Warnf("%s and has been regenerated, the configured token is revoked", reason)
+		return
+	}
+	if err := writeToken(a.path, token); err != nil {
+		a.log.Errorf("%s and has been regenerated, the configured token is revoked and the new token could not be written to '%s': %v", reason, a.path, err)
+		return
+	}
+	a.log.Warnf("%s and has been regenerated, the new token has been written to '%s'", reason, a.path)
+}
+
+// tokenClaims are claims of Kubermatic service account tokens.
+type tokenClaims struct {
+	Expiry    int64  `json:"exp"`
+	ProjectID string `json:"project_id"`
+	TokenID   string `json:"token_id"`
+}
+
+// parseTokenClaims decodes claims of a JWT token without verifying it.
+func parseTokenClaims(token string) (*tokenClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("token is not a JWT")
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("decode token claims: %v", err)
+	}
+	var claims tokenClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, fmt.Errorf("unmarshal token claims: %v", err)
+	}
+	return &claims, nil
+}
+
+func tokenExpiresWithin(token string, d time.Duration) bool {
+	claims, err := parseTokenClaims(token)
+	if err != nil || claims.Expiry == 0 {
+		return false
+	}
+	return time.Until(time.Unix(claims.Expiry, 0)) < d
+}
+
+// newServiceAccountTokenRefresh regenerates a service account token
+// through the tokens API. The token has to be allowed to manage service
+// accounts of its project.
+func newServiceAccountTokenRefresh(client *k8client.Kubermatic) func(string) (string, error) {
+	return func(token string) (string, error) {
+		claims, err := parseTokenClaims(token)
+		if err != nil {
+			return "", err
+		}
+		if claims.ProjectID == "" || claims.TokenID == "" {
+			return "", fmt.Errorf("token is not a service account token")
+		}
+
+		auth := oclient.BearerToken(token)
+		saID, t, err := findServiceAccountToken(client, auth, claims.ProjectID, claims.TokenID)
+		if err != nil {
+			return "", err
+		}
+
+		p := tokens.NewUpdateServiceAccountTokenParams()
+		p.SetProjectID(claims.ProjectID)
+		p.SetServiceAccountID(saID)
+		p.SetTokenID(t.ID)
+		p.SetBody(&models.PublicServiceAccountToken{
+			ID:   t.ID,
+			Name: t.Name,
+		})
+		r, err := client.Tokens.UpdateServiceAccountToken(p, auth)
+		if err != nil {
+			return "", fmt.Errorf("regenerate token '%s': %s", t.ID, getErrorResponse(err))
+		}
+		return r.Payload.Token, nil
+	}
+}
+
+// findServiceAccountToken looks up the service account owning the token.
+func findServiceAccountToken(client *k8client.Kubermatic, auth runtime.ClientAuthInfoWriter, projectID, tokenID string) (string, *models.PublicServiceAccountToken, error) {
+	p := serviceaccounts.NewListServiceAccountsParams()
+	p.SetProjectID(projectID)
+	sas, err := client.Serviceaccounts.ListServiceAccounts(p, auth)
+	if err != nil {
+		return "", nil, fmt.Errorf("list service accounts: %s", getErrorResponse(err))
+	}
+
+	for _, sa := range sas.Payload {
+		p := tokens.NewListServiceAccountTokensParams()
+		p.SetProjectID(projectID)
+		p.SetServiceAccountID(sa.ID)
+		ts, err := client.Tokens.ListServiceAccountTokens(p, auth)
+		if err != nil {
+			return "", nil, fmt.Errorf("list service account '%s' tokens: %s", sa.ID, getErrorResponse(err))
+		}
+		for _, t := range ts.Payload {
+			if t.ID == tokenID {
+				return sa.ID, t, nil
+			}
+		}
+	}
+
+	return "", nil, fmt.Errorf("token '%s' not found in project '%s'", tokenID, projectID)
+}
+
+// authError is returned for all requests rejected as unauthorized, so
+// resources report authentication problems the same way.
+type authError struct {
+	hint string
+}
+
+func (e *authError) Error() string {
+	return fmt.Sprintf("Kubermatic API rejected the token, %s: check provider token or token_path, or KUBERMATIC_TOKEN environment variable", e.hint)
+}
+
+// authTransport turns 401 responses into authError. Requests are retried
+// once with a regenerated token if auth is able to refresh it.
+type authTransport struct {
+	next http.RoundTripper
+	auth *tokenAuth
+}
+
+func (t *authTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.next.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+	resp.Body.Close()
+
+	token := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	if t.auth != nil && (req.Body == nil || req.GetBody != nil) {
+		if retry, err := t.retryRequest(req, token); err == nil {
+			resp, err = t.next.RoundTrip(retry)
+			if err != nil || resp.StatusCode != http.StatusUnauthorized {
+				return resp, err
+			}
+			resp.Body.Close()
+			token = strings.TrimPrefix(retry.Header.Get("Authorization"), "Bearer ")
+		}
+	}
+
+	return nil, &authError{hint: tokenExpiryHint(token, time.Now())}
+}
+
+func (t *authTransport) retryRequest(req *http.Request, rejected string) (*http.Request, error) {
+	token, err := t.auth.reauthenticate(rejected)
+	if err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		if retry.Body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	retry.Header.Set("Authorization", "Bearer "+token)
+	return retry, nil
+}
+
+// tokenExpiryHint describes token lifetime, if it can be read from the token.
+func tokenExpiryHint(token string, now time.Time) string {
+	claims, err := parseTokenClaims(token)
+	if err != nil || claims.Expiry == 0 {
+		return "token expiry is unknown"
+	}
+	d := time.Unix(claims.Expiry, 0).Sub(now).Round(time.Second)
+	if d <= 0 {
+		return fmt.Sprintf("token expired %s ago", -d)
+	}
+	return fmt.Sprintf("token expires in %s", d)
+}
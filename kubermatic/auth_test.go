@@ -0,0 +1,155 @@
+package kubermatic
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func testToken(claims string) string {
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString([]byte(`{"alg":"HS256"}`)) + "." + enc.EncodeToString([]byte(claims)) + ".signature"
+}
+
+func TestTokenExpiresWithin(t *testing.T) {
+	cases := []struct {
+		Token    string
+		Expected bool
+	}{
+		{testToken(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Minute).Unix())), true},
+		{testToken(fmt.Sprintf(`{"exp":%d}`, time.Now().Add(time.Hour).Unix())), false},
+		{testToken(`{"project_id":"abcdef"}`), false},
+		{"not-a-jwt", false},
+	}
+
+	for _, tc := range cases {
+		if got := tokenExpiresWithin(tc.Token, tokenRefreshWindow); got != tc.Expected {
+			t.Fatalf("want tokenExpiresWithin(%q)=%t, got %t", tc.Token, tc.Expected, got)
+		}
+	}
+}
+
+func TestTokenAuthRefresh(t *testing.T) {
+	expiring := testToken(fmt.Sprintf(`{"exp":%d,"project_id":"abcdef","token_id":"sa-token-1"}`, time.Now().Add(time.Minute).Unix()))
+	fresh := testToken(fmt.Sprintf(`{"exp":%d,"project_id":"abcdef","token_id":"sa-token-1"}`, time.Now().Add(time.Hour).Unix()))
+
+	calls := 0
+	a := &tokenAuth{
+		token: expiring,
+		refresh: func(token string) (string, error) {
+			calls++
+			return fresh, nil
+		},
+		log: zap.NewNop().Sugar(),
+	}
+
+	if got := a.currentToken(); got != fresh {
+		t.Fatalf("want refreshed token, got %q", got)
+	}
+	a.currentToken()
+	if calls != 1 {
+		t.Fatalf("want 1 refresh call, got %d", calls)
+	}
+
+	a = &tokenAuth{
+		token: expiring,
+		refresh: func(token string) (string, error) {
+			return "", fmt.Errorf("forbidden")
+		},
+		log: zap.NewNop().Sugar(),
+	}
+	if got := a.currentToken(); got != expiring {
+		t.Fatalf("want original token on refresh error, got %q", got)
+	}
+	if a.refresh != nil {
+		t.Fatalf("want refresh disabled after error")
+	}
+}
+
+func TestTokenExpiryHint(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	cases := []struct {
+		Token    string
+		Expected string
+	}{
+		{testToken(fmt.Sprintf(`{"exp":%d}`, now.Add(-5*time.Minute).Unix())), "token expired 5m0s ago"},
+		{testToken(fmt.Sprintf(`{"exp":%d}`, now.Add(2*time.Hour).Unix())), "token expires in 2h0m0s"},
+		{testToken(`{"project_id":"abcdef"}`), "token expiry is unknown"},
+		{"not-a-jwt", "token expiry is unknown"},
+	}
+
+	for _, tc := range cases {
+		if got := tokenExpiryHint(tc.Token, now); got != tc.Expected {
+			t.Fatalf("want tokenExpiryHint(%q)=%q, got %q", tc.Token, tc.Expected, got)
+		}
+	}
+}
+
+func TestAuthTransport(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/unauthorized" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer s.Close()
+
+	c := &http.Client{Transport: &authTransport{next: http.DefaultTransport}}
+
+	resp, err := c.Get(s.URL + "/ok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	_, err = c.Get(s.URL + "/unauthorized")
+	var ae *authError
+	if !errors.As(err, &ae) {
+		t.Fatalf("want authError, got %v", err)
+	}
+}
+
+func TestAuthTransportRetry(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer s.Close()
+
+	a := &tokenAuth{
+		token: "expired",
+		refresh: func(token string) (string, error) {
+			return "fresh", nil
+		},
+		log: zap.NewNop().Sugar(),
+	}
+	c := &http.Client{Transport: &authTransport{next: http.DefaultTransport, auth: a}}
+
+	req, err := http.NewRequest(http.MethodPost, s.URL, strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer expired")
+	resp, err := c.Do(req)
+	if err != nil {
+		t.Fatalf("want retried request to succeed, got %v", err)
+	}
+	resp.Body.Close()
+	if a.token != "fresh" {
+		t.Fatalf("want regenerated token stored, got %q", a.token)
+	}
+
+	a.refresh = nil
+	a.token = "revoked"
+	req.Header.Set("Authorization", "Bearer revoked")
+	if _, err := c.Do(req); err == nil {
+		t.Fatalf("want error without token refresh")
+	}
+}
@@ -0,0 +1,82 @@
+package kubermatic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/kubermatic/go-kubermatic/client/users"
+	"github.com/kubermatic/go-kubermatic/client/versions"
+)
+
+func dataSourceAPIHealth() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAPIHealthRead,
+
+		Schema: map[string]*schema.Schema{
+			"min_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Fail if the Kubermatic API is older than this version",
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kubermatic API version",
+			},
+			"user_email": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Email of the user the token belongs to",
+			},
+			"user_is_admin": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the user is a Kubermatic administrator",
+			},
+		},
+	}
+}
+
+func dataSourceAPIHealthRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+
+	v, err := k.client.Versions.GetKubermaticVersion(versions.NewGetKubermaticVersionParams(), k.auth)
+	if err != nil {
+		var ae *authError
+		if errors.As(err, &ae) {
+			return ae
+		}
+		if _, ok := err.(*url.Error); ok {
+			return fmt.Errorf("unable to reach Kubermatic API, check provider host: %v", err)
+		}
+		return fmt.Errorf("unable to get Kubermatic API version: %s", getErrorResponse(err))
+	}
+
+	if minVersion, ok := d.GetOk("min_version"); ok {
+		want, err := version.NewVersion(minVersion.(string))
+		if err != nil {
+			return fmt.Errorf("invalid min_version '%s': %v", minVersion, err)
+		}
+		got, err := version.NewVersion(v.Payload.API)
+		if err != nil {
+			return fmt.Errorf("unable to parse Kubermatic API version '%s': %v", v.Payload.API, err)
+		}
+		if got.LessThan(want) {
+			return fmt.Errorf("Kubermatic API version %s is older than required %s", got, want)
+		}
+	}
+
+	u, err := k.client.Users.GetCurrentUser(users.NewGetCurrentUserParams(), k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to get current user: %s", getErrorResponse(err))
+	}
+
+	d.SetId(v.Payload.API)
+	d.Set("version", v.Payload.API)
+	d.Set("user_email", u.Payload.Email)
+	d.Set("user_is_admin", u.Payload.IsAdmin)
+	return nil
+}
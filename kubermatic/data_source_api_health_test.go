@@ -0,0 +1,80 @@
+package kubermatic
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestDataSourceAPIHealthRead(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v1/version":
+			json.NewEncoder(w).Encode(&models.KubermaticVersions{API: "v2.14.3"})
+		case "/api/v1/me":
+			json.NewEncoder(w).Encode(&models.User{Email: "user@example.com", IsAdmin: true})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	cases := []struct {
+		Host  string
+		Token string
+		Input map[string]interface{}
+		Err   string
+	}{
+		{s.URL, "token", map[string]interface{}{}, ""},
+		{s.URL, "token", map[string]interface{}{"min_version": "2.14"}, ""},
+		{s.URL, "token", map[string]interface{}{"min_version": "v2.15.0"}, "Kubermatic API version 2.14.3 is older than required 2.15.0"},
+		{s.URL, "token", map[string]interface{}{"min_version": "latest"}, "invalid min_version 'latest'"},
+		{s.URL, "invalid", map[string]interface{}{}, "Kubermatic API rejected the token"},
+		{"http://127.0.0.1:0", "token", map[string]interface{}{}, "unable to reach Kubermatic API, check provider host"},
+	}
+
+	for _, tc := range cases {
+		auth := &tokenAuth{token: tc.Token}
+		client, err := newClient(tc.Host, auth, nil, defaultRequestTimeout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		k := &kubermaticProviderMeta{client: client, auth: auth}
+
+		d := schema.TestResourceDataRaw(t, dataSourceAPIHealth().Schema, tc.Input)
+		err = dataSourceAPIHealthRead(d, k)
+		if tc.Err != "" {
+			if err == nil || !strings.HasPrefix(err.Error(), tc.Err) {
+				t.Fatalf("want error %q, got %v", tc.Err, err)
+			}
+			var ae *authError
+			if tc.Token == "invalid" && !errors.As(err, &ae) {
+				t.Fatalf("want authError for rejected token, got %T", err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := d.Get("version"); got != "v2.14.3" {
+			t.Fatalf("want version %q, got %q", "v2.14.3", got)
+		}
+		if got := d.Get("user_email"); got != "user@example.com" {
+			t.Fatalf("want user_email %q, got %q", "user@example.com", got)
+		}
+		if got := d.Get("user_is_admin"); got != true {
+			t.Fatalf("want user_is_admin, got %v", got)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package kubermatic
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path"
+
+	"github.com/go-openapi/runtime"
+	oclient "github.com/go-openapi/runtime/client"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/project"
+	"gopkg.in/yaml.v2"
+)
+
+const yamlMime = "application/yaml"
+
+func dataSourceClusterKubeconfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceClusterKubeconfigRead,
+
+		Schema: clusterKubeconfigFields(map[string]*schema.Schema{}),
+	}
+}
+
+// dataSourceClusterViewerKubeconfig returns a read-only kubeconfig. Kubermatic
+// hands out the viewer kubeconfig to users of the project viewers group, so
+// the request is authenticated with a dedicated viewer service account token
+// instead of the provider credentials.
+func dataSourceClusterViewerKubeconfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceClusterViewerKubeconfigRead,
+
+		Schema: clusterKubeconfigFields(map[string]*schema.Schema{
+			"service_account_token": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Token of a project service account in the viewers group",
+			},
+		}),
+	}
+}
+
+func clusterKubeconfigFields(extra map[string]*schema.Schema) map[string]*schema.Schema {
+	fields := map[string]*schema.Schema{
+		"project_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+			Description:  "Reference project identifier",
+		},
+		"dc": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+			Description:  "Data center name",
+		},
+		"cluster_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+			Description:  "Reference cluster identifier",
+		},
+		"oidc_exec": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Authenticate kubeconfig users with the kubelogin exec credential plugin instead of the static token",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"issuer_url": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.NoZeroValues,
+						Description:  "OIDC issuer URL",
+					},
+					"client_id": {
+						Type:         schema.TypeString,
+						Required:     true,
+						ValidateFunc: validation.NoZeroValues,
+						Description:  "OIDC client identifier",
+					},
+					"client_secret": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Sensitive:   true,
+						Description: "OIDC client secret, required by confidential clients",
+					},
+					"command": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Default:     "kubectl",
+						Description: "Command running kubelogin, kubectl runs it as oidc-login plugin",
+					},
+					"extra_args": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						Description: "Additional kubelogin arguments, e.g. --oidc-extra-scope=email",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+		"kubeconfig": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Kubeconfig of the cluster",
+		},
+		"token": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "Bearer token of the kubeconfig user, empty if oidc_exec is set",
+		},
+	}
+	for k, v := range extra {
+		fields[k] = v
+	}
+	return fields
+}
+
+func dataSourceClusterKubeconfigRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	return readClusterKubeconfig(d, k, k.auth)
+}
+
+func dataSourceClusterViewerKubeconfigRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	auth := oclient.BearerToken(d.Get("service_account_token").(string))
+	return readClusterKubeconfig(d, k, auth)
+}
+
+func readClusterKubeconfig(d *schema.ResourceData, k *kubermaticProviderMeta, auth runtime.ClientAuthInfoWriter) error {
+	cID := d.Get("cluster_id").(string)
+	p := project.NewGetClusterKubeconfigParams()
+	p.SetProjectID(d.Get("project_id").(string))
+	p.SetDC(d.Get("dc").(string))
+	p.SetClusterID(cID)
+
+	raw, err := getClusterKubeconfig(k, p, auth)
+	if err != nil {
+		return fmt.Errorf("unable to get kubeconfig for cluster '%s': %v", cID, err)
+	}
+
+	token, err := kubeconfigToken(raw)
+	if err != nil {
+		return fmt.Errorf("unable to parse kubeconfig for cluster '%s': %v", cID, err)
+	}
+
+	if v, ok := d.GetOk("oidc_exec"); ok {
+		raw, err = kubeconfigWithExec(raw, expandOIDCExec(v.([]interface{})))
+		if err != nil {
+			return fmt.Errorf("unable to set exec credentials in kubeconfig for cluster '%s': %v", cID, err)
+		}
+		// the kubeconfig doesn't use the static token anymore
+		token = ""
+	}
+
+	d.SetId(cID)
+	d.Set("kubeconfig", string(raw))
+	d.Set("token", token)
+	return nil
+}
+
+// getClusterKubeconfig fetches the raw kubeconfig. The generated client can't
+// be used here, its kubeconfig model is typed against the Kubermatic cluster
+// object and drops server and certificate data, so the operation is submitted
+// with a reader passing the YAML document through unchanged.
+func getClusterKubeconfig(k *kubermaticProviderMeta, p *project.GetClusterKubeconfigParams, auth runtime.ClientAuthInfoWriter) ([]byte, error) {
+	result, err := k.client.Transport.Submit(&runtime.ClientOperation{
+		ID:                 "getClusterKubeconfig",
+		Method:             "GET",
+		PathPattern:        "/api/v1/projects/{project_id}/dc/{dc}/clusters/{cluster_id}/kubeconfig",
+		ProducesMediaTypes: []string{yamlMime},
+		ConsumesMediaTypes: []string{runtime.JSONMime},
+		Schemes:            []string{"https"},
+		Params:             p,
+		Reader:             rawResponseReader{},
+		AuthInfo:           auth,
+		Context:            p.Context,
+		Client:             p.HTTPClient,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]byte), nil
+}
+
+// rawResponseReader returns the body of a successful response as is.
+type rawResponseReader struct{}
+
+func (rawResponseReader) ReadResponse(r runtime.ClientResponse, _ runtime.Consumer) (interface{}, error) {
+	body, err := ioutil.ReadAll(r.Body())
+	if err != nil {
+		return nil, err
+	}
+	if r.Code() != http.StatusOK {
+		return nil, fmt.Errorf("[%d] %s", r.Code(), body)
+	}
+	return body, nil
+}
+
+func kubeconfigToken(raw []byte) (string, error) {
+	var cfg struct {
+		Users []struct {
+			User struct {
+				Token string `yaml:"token"`
+			} `yaml:"user"`
+		} `yaml:"users"`
+	}
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return "", err
+	}
+	for _, u := range cfg.Users {
+		if u.User.Token != "" {
+			return u.User.Token, nil
+		}
+	}
+	return "", nil
+}
+
+// oidcExec configures kubeconfig users getting tokens through kubelogin.
+type oidcExec struct {
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	command      string
+	extraArgs    []string
+}
+
+func expandOIDCExec(p []interface{}) oidcExec {
+	var e oidcExec
+	if len(p) < 1 || p[0] == nil {
+		return e
+	}
+	in := p[0].(map[string]interface{})
+
+	if v, ok := in["issuer_url"]; ok {
+		e.issuerURL = v.(string)
+	}
+	if v, ok := in["client_id"]; ok {
+		e.clientID = v.(string)
+	}
+	if v, ok := in["client_secret"]; ok {
+		e.clientSecret = v.(string)
+	}
+	if v, ok := in["command"]; ok {
+		e.command = v.(string)
+	}
+	if v, ok := in["extra_args"]; ok {
+		for _, arg := range v.([]interface{}) {
+			e.extraArgs = append(e.extraArgs, arg.(string))
+		}
+	}
+	return e
+}
+
+// kubeconfigWithExec replaces credentials of all kubeconfig users with the
+// kubelogin exec credential plugin.
+func kubeconfigWithExec(raw []byte, e oidcExec) ([]byte, error) {
+	var cfg map[string]interface{}
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return nil, err
+	}
+
+	var args []string
+	if path.Base(e.command) == "kubectl" {
+		args = append(args, "oidc-login")
+	}
+	args = append(args, "get-token", "--oidc-issuer-url="+e.issuerURL, "--oidc-client-id="+e.clientID)
+	if e.clientSecret != "" {
+		args = append(args, "--oidc-client-secret="+e.clientSecret)
+	}
+	args = append(args, e.extraArgs...)
+
+	users, _ := cfg["users"].([]interface{})
+	for _, u := range users {
+		user, ok := u.(map[interface{}]interface{})
+		if !ok {
+			continue
+		}
+		user["user"] = map[string]interface{}{
+			"exec": map[string]interface{}{
+				"apiVersion": "client.authentication.k8s.io/v1beta1",
+				"command":    e.command,
+				"args":       args,
+			},
+		}
+	}
+
+	return yaml.Marshal(cfg)
+}
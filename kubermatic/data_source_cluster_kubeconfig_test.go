@@ -0,0 +1,169 @@
+package kubermatic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestKubeconfigToken(t *testing.T) {
+	cases := []struct {
+		Input          string
+		ExpectedOutput string
+	}{
+		{
+			`apiVersion: v1
+clusters:
+- cluster:
+    server: https://abcdef.europe-west3-c.dev.kubermatic.io:31554
+  name: abcdef
+users:
+- name: admin
+  user:
+    token: abcdef.0123456789abcdef
+`,
+			"abcdef.0123456789abcdef",
+		},
+		{
+			`apiVersion: v1
+users:
+- name: default
+  user: {}
+`,
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		output, err := kubeconfigToken([]byte(tc.Input))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if output != tc.ExpectedOutput {
+			t.Fatalf("Unexpected output: want %q, got %q", tc.ExpectedOutput, output)
+		}
+	}
+}
+
+func TestKubeconfigWithExec(t *testing.T) {
+	cases := []struct {
+		Input          oidcExec
+		ExpectedOutput string
+	}{
+		{
+			oidcExec{
+				issuerURL: "https://dev.kubermatic.io/dex",
+				clientID:  "kubermatic",
+				command:   "kubectl",
+				extraArgs: []string{"--oidc-extra-scope=email"},
+			},
+			`apiVersion: v1
+clusters:
+- cluster:
+    server: https://abcdef.europe-west3-c.dev.kubermatic.io:31554
+  name: abcdef
+users:
+- name: admin
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      args:
+      - oidc-login
+      - get-token
+      - --oidc-issuer-url=https://dev.kubermatic.io/dex
+      - --oidc-client-id=kubermatic
+      - --oidc-extra-scope=email
+      command: kubectl
+`,
+		},
+		{
+			oidcExec{
+				issuerURL:    "https://dev.kubermatic.io/dex",
+				clientID:     "kubermatic",
+				clientSecret: "secret",
+				command:      "/usr/local/bin/kubelogin",
+			},
+			`apiVersion: v1
+clusters:
+- cluster:
+    server: https://abcdef.europe-west3-c.dev.kubermatic.io:31554
+  name: abcdef
+users:
+- name: admin
+  user:
+    exec:
+      apiVersion: client.authentication.k8s.io/v1beta1
+      args:
+      - get-token
+      - --oidc-issuer-url=https://dev.kubermatic.io/dex
+      - --oidc-client-id=kubermatic
+      - --oidc-client-secret=secret
+      command: /usr/local/bin/kubelogin
+`,
+		},
+	}
+
+	input := `apiVersion: v1
+clusters:
+- cluster:
+    server: https://abcdef.europe-west3-c.dev.kubermatic.io:31554
+  name: abcdef
+users:
+- name: admin
+  user:
+    token: abcdef.0123456789abcdef
+`
+	for _, tc := range cases {
+		output, err := kubeconfigWithExec([]byte(input), tc.Input)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if string(output) != tc.ExpectedOutput {
+			t.Fatalf("Unexpected output: want %q, got %q", tc.ExpectedOutput, output)
+		}
+	}
+
+	// the static token is not exported once the kubeconfig uses exec
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", yamlMime)
+		w.Write([]byte(input))
+	}))
+	defer s.Close()
+
+	client, err := newClient(s.URL, &tokenAuth{token: "token"}, nil, defaultRequestTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &kubermaticProviderMeta{client: client, auth: &tokenAuth{token: "token"}}
+
+	for _, exec := range []bool{false, true} {
+		raw := map[string]interface{}{
+			"project_id": "project",
+			"dc":         "seed",
+			"cluster_id": "abcdef",
+		}
+		if exec {
+			raw["oidc_exec"] = []interface{}{
+				map[string]interface{}{
+					"issuer_url": "https://dev.kubermatic.io/dex",
+					"client_id":  "kubermatic",
+				},
+			}
+		}
+		d := schema.TestResourceDataRaw(t, dataSourceClusterKubeconfig().Schema, raw)
+		if err := dataSourceClusterKubeconfigRead(d, k); err != nil {
+			t.Fatal(err)
+		}
+
+		token, kubeconfig := d.Get("token").(string), d.Get("kubeconfig").(string)
+		if exec && (token != "" || strings.Contains(kubeconfig, "abcdef.0123456789abcdef")) {
+			t.Fatalf("want no static token with oidc_exec, got token %q and kubeconfig:\n%s", token, kubeconfig)
+		}
+		if !exec && token != "abcdef.0123456789abcdef" {
+			t.Fatalf("want static token %q, got %q", "abcdef.0123456789abcdef", token)
+		}
+	}
+}
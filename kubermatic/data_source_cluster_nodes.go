@@ -0,0 +1,262 @@
+package kubermatic
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/metric"
+	"github.com/kubermatic/go-kubermatic/client/project"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+// dataSourceClusterNodes lists nodes through the node deployments of the
+// cluster, nodes not belonging to a node deployment are not listed.
+func dataSourceClusterNodes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceClusterNodesRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Reference project identifier",
+			},
+			"dc": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Data center name",
+			},
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Reference cluster identifier",
+			},
+			"include_metrics": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to fetch CPU and memory metrics of the nodes",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Nodes of the cluster, only nodes belonging to node deployments are listed",
+				Elem: &schema.Resource{
+					Schema: clusterNodeFields(),
+				},
+			},
+		},
+	}
+}
+
+func clusterNodeFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Node identifier",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Node name",
+		},
+		"machine_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the machine backing the node",
+		},
+		"kubelet_version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Kubelet version",
+		},
+		"operating_system": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Operating system reported by the node",
+		},
+		"kernel_version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Kernel version",
+		},
+		"architecture": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "CPU architecture",
+		},
+		"container_runtime": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Container runtime",
+		},
+		"container_runtime_version": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Container runtime version",
+		},
+		"addresses": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Node addresses",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Address type",
+					},
+					"address": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Address",
+					},
+				},
+			},
+		},
+		"allocatable": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Resources available for scheduling",
+			Elem: &schema.Resource{
+				Schema: nodeResourcesFields(),
+			},
+		},
+		"capacity": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Total resources of the node",
+			Elem: &schema.Resource{
+				Schema: nodeResourcesFields(),
+			},
+		},
+		"error_message": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Error reported while provisioning the node",
+		},
+		"metrics": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Node metrics, only set when include_metrics is enabled",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"cpu_total_millicores": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Total CPU of the node in millicores",
+					},
+					"cpu_available_millicores": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "CPU available on the node in millicores",
+					},
+					"cpu_used_percentage": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Percentage of the node CPU in use",
+					},
+					"memory_total_bytes": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Total memory of the node in bytes",
+					},
+					"memory_available_bytes": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Memory available on the node in bytes",
+					},
+					"memory_used_percentage": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "Percentage of the node memory in use",
+					},
+				},
+			},
+		},
+	}
+}
+
+func nodeResourcesFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"cpu": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "CPU quantity",
+		},
+		"memory": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Memory quantity",
+		},
+	}
+}
+
+func dataSourceClusterNodesRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID := d.Get("project_id").(string)
+	dc := d.Get("dc").(string)
+	cID := d.Get("cluster_id").(string)
+
+	p := project.NewListNodeDeploymentsParams()
+	p.SetProjectID(pID)
+	p.SetDC(dc)
+	p.SetClusterID(cID)
+
+	r, err := k.client.Project.ListNodeDeployments(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to list node deployments of cluster '%s': %s", cID, getErrorResponse(err))
+	}
+
+	var nodes []*models.Node
+	var metrics map[string]*models.NodeMetric
+	if d.Get("include_metrics").(bool) {
+		metrics = make(map[string]*models.NodeMetric)
+	}
+	for _, nd := range r.Payload {
+		p := project.NewListNodeDeploymentNodesParams()
+		p.SetProjectID(pID)
+		p.SetDC(dc)
+		p.SetClusterID(cID)
+		p.SetNodeDeploymentID(nd.ID)
+
+		r, err := k.client.Project.ListNodeDeploymentNodes(p, k.auth)
+		if err != nil {
+			return fmt.Errorf("unable to list nodes of node deployment '%s': %s", nd.ID, getErrorResponse(err))
+		}
+		nodes = append(nodes, r.Payload...)
+
+		if metrics != nil {
+			if err := listNodeDeploymentMetrics(k, pID, dc, cID, nd.ID, metrics); err != nil {
+				return err
+			}
+		}
+	}
+
+	d.SetId(cID)
+	return d.Set("nodes", flattenClusterNodes(nodes, metrics))
+}
+
+// listNodeDeploymentMetrics adds metrics of the node deployment nodes keyed
+// by node name, API only exposes metrics per node deployment.
+func listNodeDeploymentMetrics(k *kubermaticProviderMeta, pID, dc, cID, nID string, metrics map[string]*models.NodeMetric) error {
+	p := metric.NewListNodeDeploymentMetricsParams()
+	p.SetProjectID(pID)
+	p.SetDC(dc)
+	p.SetClusterID(cID)
+	p.SetNodeDeploymentID(nID)
+
+	r, err := k.client.Metric.ListNodeDeploymentMetrics(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to get metrics of node deployment '%s': %s", nID, getErrorResponse(err))
+	}
+	for _, v := range r.Payload {
+		metrics[v.Name] = v
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package kubermatic
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/project"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func dataSourceClusterSpec() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceClusterSpecRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Reference project identifier",
+			},
+			"dc": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Data center name",
+			},
+			"cluster_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Reference cluster identifier",
+			},
+			"spec_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Cluster specification as JSON with sorted keys, cloud credentials are removed",
+			},
+		},
+	}
+}
+
+func dataSourceClusterSpecRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	cID := d.Get("cluster_id").(string)
+
+	p := project.NewGetClusterParams()
+	p.SetProjectID(d.Get("project_id").(string))
+	p.SetDC(d.Get("dc").(string))
+	p.SetClusterID(cID)
+
+	r, err := k.client.Project.GetCluster(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to get cluster '%s': %s", cID, getErrorResponse(err))
+	}
+
+	spec, err := clusterSpecJSON(r.Payload.Spec)
+	if err != nil {
+		return fmt.Errorf("unable to export cluster '%s' spec: %v", cID, err)
+	}
+
+	d.SetId(cID)
+	return d.Set("spec_json", spec)
+}
+
+// clusterSpecJSON encodes the spec through a generic map, so keys are
+// sorted and the output is stable between reads.
+func clusterSpecJSON(in *models.ClusterSpec) (string, error) {
+	if in == nil {
+		return "{}", nil
+	}
+
+	b, err := json.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+	var spec map[string]interface{}
+	if err := json.Unmarshal(b, &spec); err != nil {
+		return "", err
+	}
+
+	if cloud, ok := spec["cloud"]; ok {
+		removeCredentials(cloud)
+	}
+
+	b, err = json.Marshal(spec)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func removeCredentials(in interface{}) {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if cloudCredentialKeys[key] {
+				delete(v, key)
+				continue
+			}
+			removeCredentials(val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			removeCredentials(val)
+		}
+	}
+}
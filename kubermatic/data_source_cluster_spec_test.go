@@ -0,0 +1,43 @@
+package kubermatic
+
+import (
+	"testing"
+
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestClusterSpecJSON(t *testing.T) {
+	cases := []struct {
+		Input          *models.ClusterSpec
+		ExpectedOutput string
+	}{
+		{
+			&models.ClusterSpec{
+				Version: "1.17.4",
+				Cloud: &models.CloudSpec{
+					DatacenterName: "eu-west-1",
+					Openstack: &models.OpenstackCloudSpec{
+						FloatingIPPool: "ext-net",
+						Username:       "user",
+						Password:       "secret",
+					},
+				},
+			},
+			`{"admissionPlugins":null,"cloud":{"dc":"eu-west-1","openstack":{"credentialsReference":{},"floatingIpPool":"ext-net"}},"machineNetworks":null,"version":"1.17.4"}`,
+		},
+		{
+			nil,
+			"{}",
+		},
+	}
+
+	for _, tc := range cases {
+		output, err := clusterSpecJSON(tc.Input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if output != tc.ExpectedOutput {
+			t.Fatalf("want %s, got %s", tc.ExpectedOutput, output)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package kubermatic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/kubermatic/go-kubermatic/client/datacenter"
+	"github.com/kubermatic/go-kubermatic/client/users"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func dataSourceDatacenters() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceDatacentersRead,
+
+		Schema: map[string]*schema.Schema{
+			"provider_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return data centers of the cloud provider",
+			},
+			"seed": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return data centers of the seed",
+			},
+			"usable_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Only return data centers the current user's email domain is allowed to use",
+			},
+			"datacenters": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Data centers matching the filters",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Data center name",
+						},
+						"seed": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Seed the data center belongs to",
+						},
+						"provider_name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Cloud provider",
+						},
+						"country": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Country code",
+						},
+						"location": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Location",
+						},
+						"enforce_audit_logging": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether audit logging is enforced for clusters",
+						},
+						"enforce_pod_security_policy": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether pod security policy is enforced for clusters",
+						},
+						"required_email_domains": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "Email domains allowed to use the data center, any domain is allowed if empty",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceDatacentersRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+
+	r, err := k.client.Datacenter.ListDatacenters(datacenter.NewListDatacentersParams(), k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to list data centers: %s", getErrorResponse(err))
+	}
+
+	var email string
+	if d.Get("usable_only").(bool) {
+		u, err := k.client.Users.GetCurrentUser(users.NewGetCurrentUserParams(), k.auth)
+		if err != nil {
+			return fmt.Errorf("unable to get current user: %s", getErrorResponse(err))
+		}
+		email = u.Payload.Email
+	}
+
+	provider := d.Get("provider_name").(string)
+	seed := d.Get("seed").(string)
+
+	var dcs []*models.Datacenter
+	for _, dc := range r.Payload {
+		// seeds are listed among data centers but can't host clusters
+		if dc.Seed || dc.Spec == nil || dc.Metadata == nil {
+			continue
+		}
+		if provider != "" && dc.Spec.Provider != provider {
+			continue
+		}
+		if seed != "" && dc.Spec.Seed != seed {
+			continue
+		}
+		if email != "" && !emailDomainAllowed(email, datacenterRequiredEmailDomains(dc.Spec)) {
+			continue
+		}
+		dcs = append(dcs, dc)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s/%s", provider, seed, email))
+	return d.Set("datacenters", flattenDatacenters(dcs))
+}
+
+// datacenterRequiredEmailDomains merges deprecated single domain field
+// with the list of domains.
+func datacenterRequiredEmailDomains(in *models.DatacenterSpec) []string {
+	domains := in.RequiredEmailDomains
+	if in.RequiredEmailDomain != "" {
+		domains = append([]string{in.RequiredEmailDomain}, domains...)
+	}
+	return domains
+}
+
+func emailDomainAllowed(email string, domains []string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+	i := strings.LastIndex(email, "@")
+	if i < 0 {
+		return false
+	}
+	for _, domain := range domains {
+		if strings.EqualFold(email[i+1:], domain) {
+			return true
+		}
+	}
+	return false
+}
+
+func flattenDatacenters(in []*models.Datacenter) []interface{} {
+	if len(in) < 1 {
+		return []interface{}{}
+	}
+
+	att := make([]interface{}, len(in))
+
+	for i, v := range in {
+		domains := datacenterRequiredEmailDomains(v.Spec)
+		ds := make([]interface{}, len(domains))
+		for i, s := range domains {
+			ds[i] = s
+		}
+
+		att[i] = map[string]interface{}{
+			"name":                        v.Metadata.Name,
+			"seed":                        v.Spec.Seed,
+			"provider_name":               v.Spec.Provider,
+			"country":                     v.Spec.Country,
+			"location":                    v.Spec.Location,
+			"enforce_audit_logging":       v.Spec.EnforceAuditLogging,
+			"enforce_pod_security_policy": v.Spec.EnforcePodSecurityPolicy,
+			"required_email_domains":      ds,
+		}
+	}
+
+	return att
+}
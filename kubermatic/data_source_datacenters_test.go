@@ -0,0 +1,24 @@
+package kubermatic
+
+import (
+	"testing"
+)
+
+func TestEmailDomainAllowed(t *testing.T) {
+	cases := []struct {
+		Email   string
+		Domains []string
+		Allowed bool
+	}{
+		{"jane@example.com", nil, true},
+		{"jane@example.com", []string{"example.org", "Example.com"}, true},
+		{"jane@example.com", []string{"example.org"}, false},
+		{"jane", []string{"example.com"}, false},
+	}
+
+	for _, tc := range cases {
+		if allowed := emailDomainAllowed(tc.Email, tc.Domains); allowed != tc.Allowed {
+			t.Fatalf("want emailDomainAllowed(%q, %v)=%t, got %t", tc.Email, tc.Domains, tc.Allowed, allowed)
+		}
+	}
+}
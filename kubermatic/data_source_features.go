@@ -0,0 +1,65 @@
+package kubermatic
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/kubermatic/go-kubermatic/client/admin"
+	"github.com/kubermatic/go-kubermatic/client/versions"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func dataSourceFeatures() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceFeaturesRead,
+
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Kubermatic API version",
+			},
+			"features": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Cluster features enabled in global settings, dashboard access and OIDC kubeconfig. The API has no feature flags, enterprise features are not listed",
+				Elem: &schema.Schema{
+					Type: schema.TypeBool,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceFeaturesRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+
+	v, err := k.client.Versions.GetKubermaticVersion(versions.NewGetKubermaticVersionParams(), k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to get Kubermatic API version: %s", getErrorResponse(err))
+	}
+
+	s, err := k.client.Admin.GetKubermaticSettings(admin.NewGetKubermaticSettingsParams(), k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to get Kubermatic settings: %s", getErrorResponse(err))
+	}
+
+	d.SetId(v.Payload.API)
+	d.Set("version", v.Payload.API)
+	return d.Set("features", flattenFeatures(s.Payload))
+}
+
+// flattenFeatures maps global settings toggles of cluster features to
+// feature names, dashboard UI toggles are not features. Enterprise features
+// like metering, quotas, MLA or OPA have no settings in this API version and
+// are not listed.
+func flattenFeatures(in *models.GlobalSettings) map[string]interface{} {
+	if in == nil {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"dashboard":       in.EnableDashboard,
+		"oidc_kubeconfig": in.EnableOIDCKubeconfig,
+	}
+}
@@ -0,0 +1,49 @@
+package kubermatic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestFlattenFeatures(t *testing.T) {
+	cases := []struct {
+		Input          *models.GlobalSettings
+		ExpectedOutput map[string]interface{}
+	}{
+		{
+			&models.GlobalSettings{
+				SettingSpec: models.SettingSpec{
+					EnableDashboard:       true,
+					EnableOIDCKubeconfig:  false,
+					DisplayAPIDocs:        true,
+					DisplayDemoInfo:       true,
+					DisplayTermsOfService: true,
+				},
+			},
+			map[string]interface{}{
+				"dashboard":       true,
+				"oidc_kubeconfig": false,
+			},
+		},
+		{
+			&models.GlobalSettings{},
+			map[string]interface{}{
+				"dashboard":       false,
+				"oidc_kubeconfig": false,
+			},
+		},
+		{
+			nil,
+			map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenFeatures(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package kubermatic
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/datacenter"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func dataSourceOperatingSystems() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceOperatingSystemsRead,
+
+		Schema: map[string]*schema.Schema{
+			"dc": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Data center name",
+			},
+			"provider_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Fail if the data center is not of this cloud provider",
+			},
+			"operating_systems": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Operating systems with images configured in the data center",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Operating system name",
+						},
+						"image": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Default image or template of the operating system",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceOperatingSystemsRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	dc := d.Get("dc").(string)
+
+	p := datacenter.NewGetDatacenterParams()
+	p.SetDC(dc)
+	r, err := k.client.Datacenter.GetDatacenter(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to get data center '%s': %s", dc, getErrorResponse(err))
+	}
+	if r.Payload.Spec == nil {
+		return fmt.Errorf("data center '%s' has no specification", dc)
+	}
+
+	if v, ok := d.GetOk("provider_name"); ok && v.(string) != r.Payload.Spec.Provider {
+		return fmt.Errorf("data center '%s' is of provider '%s', not '%s'", dc, r.Payload.Spec.Provider, v)
+	}
+
+	d.SetId(dc)
+	return d.Set("operating_systems", flattenDatacenterImages(r.Payload.Spec))
+}
+
+// flattenDatacenterImages flattens per operating system images of the
+// data center provider, sorted by operating system.
+func flattenDatacenterImages(in *models.DatacenterSpec) []interface{} {
+	var images models.ImageList
+	switch {
+	case in.Aws != nil:
+		images = in.Aws.Images
+	case in.Openstack != nil:
+		images = in.Openstack.Images
+	case in.Vsphere != nil:
+		images = in.Vsphere.Templates
+	}
+
+	names := make([]string, 0, len(images))
+	for name := range images {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	att := make([]interface{}, len(names))
+	for i, name := range names {
+		att[i] = map[string]interface{}{
+			"name":  name,
+			"image": images[name],
+		}
+	}
+	return att
+}
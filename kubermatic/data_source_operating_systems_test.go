@@ -0,0 +1,41 @@
+package kubermatic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestFlattenDatacenterImages(t *testing.T) {
+	cases := []struct {
+		Input          *models.DatacenterSpec
+		ExpectedOutput []interface{}
+	}{
+		{
+			&models.DatacenterSpec{
+				Openstack: &models.DatacenterSpecOpenstack{
+					Images: models.ImageList{
+						"ubuntu": "Ubuntu Bionic",
+						"centos": "CentOS 7",
+					},
+				},
+			},
+			[]interface{}{
+				map[string]interface{}{"name": "centos", "image": "CentOS 7"},
+				map[string]interface{}{"name": "ubuntu", "image": "Ubuntu Bionic"},
+			},
+		},
+		{
+			&models.DatacenterSpec{},
+			[]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenDatacenterImages(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package kubermatic
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/credentials"
+)
+
+// presetProviders are cloud providers presets can be defined for.
+var presetProviders = []string{
+	"alibaba",
+	"aws",
+	"azure",
+	"digitalocean",
+	"gcp",
+	"hetzner",
+	"kubevirt",
+	"openstack",
+	"packet",
+	"vsphere",
+}
+
+func dataSourcePreset() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourcePresetRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Preset name",
+			},
+			"provider_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice(presetProviders, false),
+				Description:  "Cloud provider the preset must be available for, all providers are checked if not set",
+			},
+			"dc": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Data center the preset must be available for",
+			},
+			"providers": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Cloud providers the preset is available for",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePresetRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	name := d.Get("name").(string)
+
+	providers := presetProviders
+	if v, ok := d.GetOk("provider_name"); ok {
+		providers = []string{v.(string)}
+	}
+
+	var dc *string
+	if v, ok := d.GetOk("dc"); ok {
+		dc = strToPtr(v.(string))
+	}
+
+	var found []string
+	for _, provider := range providers {
+		p := credentials.NewListCredentialsParams()
+		p.SetProviderName(provider)
+		p.SetDatacenter(dc)
+
+		r, err := k.client.Credentials.ListCredentials(p, k.auth)
+		if err != nil {
+			return fmt.Errorf("unable to list presets for provider '%s': %s", provider, getErrorResponse(err))
+		}
+
+		for _, n := range r.Payload.Names {
+			if n == name {
+				found = append(found, provider)
+				break
+			}
+		}
+	}
+
+	if len(found) == 0 {
+		msg := fmt.Sprintf("preset '%s' is not available for provider(s) %s", name, strings.Join(providers, ", "))
+		if dc != nil {
+			msg += fmt.Sprintf(" in data center '%s'", *dc)
+		}
+		return errors.New(msg)
+	}
+
+	d.SetId(name)
+	return d.Set("providers", found)
+}
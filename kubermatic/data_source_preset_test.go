@@ -0,0 +1,101 @@
+package kubermatic
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+func TestDataSourcePresetRead(t *testing.T) {
+	// presets by provider, those with a data center suffix are only
+	// listed for that data center
+	presets := map[string][]string{
+		"aws":       {"default", "restricted@aws-eu"},
+		"gcp":       {"default"},
+		"openstack": {"restricted@os-de"},
+	}
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		provider := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/api/v1/providers/"), "/presets/credentials")
+		dc := r.URL.Query().Get("datacenter")
+
+		names := []string{}
+		for _, n := range presets[provider] {
+			parts := strings.SplitN(n, "@", 2)
+			if len(parts) == 2 && parts[1] != dc {
+				continue
+			}
+			names = append(names, parts[0])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"names": names})
+	}))
+	defer s.Close()
+
+	client, err := newClient(s.URL, &tokenAuth{token: "token"}, nil, defaultRequestTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &kubermaticProviderMeta{client: client, auth: &tokenAuth{token: "token"}}
+
+	cases := []struct {
+		Input     map[string]interface{}
+		Providers []string
+		Err       string
+	}{
+		{
+			map[string]interface{}{"name": "default"},
+			[]string{"aws", "gcp"},
+			"",
+		},
+		{
+			map[string]interface{}{"name": "default", "provider_name": "gcp"},
+			[]string{"gcp"},
+			"",
+		},
+		{
+			map[string]interface{}{"name": "restricted", "dc": "os-de"},
+			[]string{"openstack"},
+			"",
+		},
+		{
+			map[string]interface{}{"name": "restricted", "provider_name": "aws", "dc": "os-de"},
+			nil,
+			"preset 'restricted' is not available for provider(s) aws in data center 'os-de'",
+		},
+		{
+			map[string]interface{}{"name": "unknown", "provider_name": "hetzner"},
+			nil,
+			"preset 'unknown' is not available for provider(s) hetzner",
+		},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, dataSourcePreset().Schema, tc.Input)
+		err := dataSourcePresetRead(d, k)
+		if tc.Err != "" {
+			if err == nil || err.Error() != tc.Err {
+				t.Fatalf("want error %q, got %v", tc.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var providers []string
+		for _, v := range d.Get("providers").([]interface{}) {
+			providers = append(providers, v.(string))
+		}
+		if diff := cmp.Diff(tc.Providers, providers); diff != "" {
+			t.Fatalf("Unexpected output from expander: mismatch (-want +got):\n%s", diff)
+		}
+		if d.Id() != tc.Input["name"] {
+			t.Fatalf("want id %q, got %q", tc.Input["name"], d.Id())
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package kubermatic
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/project"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func dataSourceProject() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceProjectRead,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"project_id", "name"},
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Project identifier",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"project_id", "name"},
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Project name, must be unique among projects of the user",
+			},
+			"labels": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Project labels",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status represents the current state of the project",
+			},
+			"owners": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Owners of the project",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the owner",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email of the owner",
+						},
+					},
+				},
+			},
+			"clusters_number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of clusters in the project",
+			},
+			"creation_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp",
+			},
+		},
+	}
+}
+
+func dataSourceProjectRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+
+	var rec *models.Project
+	if id, ok := d.GetOk("project_id"); ok {
+		p := project.NewGetProjectParams()
+		r, err := k.client.Project.GetProject(p.WithProjectID(id.(string)), k.auth)
+		if err != nil {
+			return fmt.Errorf("unable to get project '%s': %s", id, getErrorResponse(err))
+		}
+		rec = r.Payload
+	} else {
+		name := d.Get("name").(string)
+		found, err := findProjectByName(k, name)
+		if err != nil {
+			return err
+		}
+		if found == nil {
+			return fmt.Errorf("no project named '%s' found", name)
+		}
+		rec = found
+	}
+
+	d.SetId(rec.ID)
+	d.Set("project_id", rec.ID)
+	d.Set("name", rec.Name)
+	if err := d.Set("labels", rec.Labels); err != nil {
+		return err
+	}
+	d.Set("status", rec.Status)
+	if err := d.Set("owners", flattenProjectOwners(rec.Owners)); err != nil {
+		return err
+	}
+	d.Set("clusters_number", rec.ClustersNumber)
+	d.Set("creation_timestamp", rec.CreationTimestamp.String())
+	return nil
+}
+
+func flattenProjectOwners(owners []*models.User) []interface{} {
+	var out []interface{}
+	for _, o := range owners {
+		if o == nil {
+			continue
+		}
+		out = append(out, map[string]interface{}{
+			"name":  o.Name,
+			"email": o.Email,
+		})
+	}
+	return out
+}
@@ -0,0 +1,50 @@
+package kubermatic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccKubermaticProjectDataSource_Basic(t *testing.T) {
+	projectName := randomTestName()
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubermaticProjectDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckKubermaticProjectDataSourceConfigBasic, projectName),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.kubermatic_project.by_id", "name", "kubermatic_project.foobar", "name"),
+					resource.TestCheckResourceAttrPair(
+						"data.kubermatic_project.by_name", "project_id", "kubermatic_project.foobar", "id"),
+					resource.TestCheckResourceAttr(
+						"data.kubermatic_project.by_name", "labels.foo", "bar"),
+					resource.TestCheckResourceAttr(
+						"data.kubermatic_project.by_name", "clusters_number", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckKubermaticProjectDataSourceConfigBasic = `
+resource "kubermatic_project" "foobar" {
+	name = "%s"
+	labels = {
+		"foo" = "bar"
+	}
+}
+
+data "kubermatic_project" "by_id" {
+	project_id = kubermatic_project.foobar.id
+}
+
+data "kubermatic_project" "by_name" {
+	name = kubermatic_project.foobar.name
+}
+`
@@ -0,0 +1,135 @@
+package kubermatic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/kubermatic/go-kubermatic/client/project"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func dataSourceProjects() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceProjectsRead,
+
+		Schema: map[string]*schema.Schema{
+			"labels": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Only return projects having all the labels",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"projects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Projects matching the labels, sorted by name",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"project_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Project identifier",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Project name",
+						},
+						"labels": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "Project labels",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Status represents the current state of the project",
+						},
+						"clusters_number": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Number of clusters in the project",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProjectsRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+
+	r, err := k.client.Project.ListProjects(project.NewListProjectsParams(), k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to list projects: %s", getErrorResponse(err))
+	}
+
+	selector := make(map[string]string)
+	for key, val := range d.Get("labels").(map[string]interface{}) {
+		selector[key] = val.(string)
+	}
+
+	var projects []*models.Project
+	for _, p := range r.Payload {
+		if p != nil && labelsMatch(p.Labels, selector) {
+			projects = append(projects, p)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool {
+		if projects[i].Name == projects[j].Name {
+			return projects[i].ID < projects[j].ID
+		}
+		return projects[i].Name < projects[j].Name
+	})
+
+	d.SetId(labelSelectorID(selector))
+	return d.Set("projects", flattenProjects(projects))
+}
+
+// labelsMatch reports whether labels contain all the selector labels.
+func labelsMatch(labels, selector map[string]string) bool {
+	for key, val := range selector {
+		if v, ok := labels[key]; !ok || v != val {
+			return false
+		}
+	}
+	return true
+}
+
+// labelSelectorID returns sorted key=value pairs of the selector.
+func labelSelectorID(selector map[string]string) string {
+	pairs := make([]string, 0, len(selector))
+	for key, val := range selector {
+		pairs = append(pairs, key+"="+val)
+	}
+	sort.Strings(pairs)
+	return "projects/" + strings.Join(pairs, ",")
+}
+
+func flattenProjects(in []*models.Project) []interface{} {
+	if len(in) < 1 {
+		return []interface{}{}
+	}
+
+	att := make([]interface{}, len(in))
+
+	for i, v := range in {
+		att[i] = map[string]interface{}{
+			"project_id":      v.ID,
+			"name":            v.Name,
+			"labels":          v.Labels,
+			"status":          v.Status,
+			"clusters_number": v.ClustersNumber,
+		}
+	}
+
+	return att
+}
@@ -0,0 +1,25 @@
+package kubermatic
+
+import (
+	"testing"
+)
+
+func TestLabelsMatch(t *testing.T) {
+	labels := map[string]string{"env": "prod", "team": "platform"}
+	cases := []struct {
+		Selector map[string]string
+		Match    bool
+	}{
+		{nil, true},
+		{map[string]string{"env": "prod"}, true},
+		{map[string]string{"env": "prod", "team": "platform"}, true},
+		{map[string]string{"env": "dev"}, false},
+		{map[string]string{"env": "prod", "owner": ""}, false},
+	}
+
+	for _, tc := range cases {
+		if match := labelsMatch(labels, tc.Selector); match != tc.Match {
+			t.Fatalf("want labelsMatch(%v, %v)=%t, got %t", labels, tc.Selector, tc.Match, match)
+		}
+	}
+}
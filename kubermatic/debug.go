@@ -0,0 +1,89 @@
+package kubermatic
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// longest logged body, the rest is truncated
+const maxLoggedBodySize = 4096
+
+// debugTransport logs method, path and status of every API call, with
+// bodies having secrets redacted.
+type debugTransport struct {
+	next http.RoundTripper
+	log  *zap.SugaredLogger
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody string
+	if req.Body != nil && req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := ioutil.ReadAll(body)
+			body.Close()
+			reqBody = redactBody(req.Header.Get("Content-Type"), b)
+		}
+	}
+	t.log.Debugw("API request", "method", req.Method, "url", req.URL.Redacted(), "body", reqBody)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		t.log.Debugw("API request failed", "method", req.Method, "url", req.URL.Redacted(), "error", err)
+		return resp, err
+	}
+
+	b, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	t.log.Debugw("API response", "method", req.Method, "url", req.URL.Redacted(), "status", resp.StatusCode,
+		"duration", time.Since(start), "body", redactBody(resp.Header.Get("Content-Type"), b))
+
+	return resp, nil
+}
+
+// redactBody returns JSON and form encoded bodies with secrets redacted,
+// other bodies like kubeconfigs are not logged.
+func redactBody(contentType string, b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	var out string
+	switch mediaType {
+	case "application/json":
+		var err error
+		if out, err = redactJSON(b); err != nil {
+			return fmt.Sprintf("(%d bytes of invalid JSON)", len(b))
+		}
+	case "application/x-www-form-urlencoded":
+		v, err := url.ParseQuery(string(b))
+		if err != nil {
+			return fmt.Sprintf("(%d bytes of invalid form)", len(b))
+		}
+		for key := range v {
+			if isSecretKey(key) {
+				v.Set(key, redacted)
+			}
+		}
+		out = v.Encode()
+	default:
+		return fmt.Sprintf("(%d bytes of %s)", len(b), contentType)
+	}
+
+	if len(out) > maxLoggedBodySize {
+		out = out[:maxLoggedBodySize] + "...(truncated)"
+	}
+	return out
+}
@@ -0,0 +1,110 @@
+package kubermatic
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactBody(t *testing.T) {
+	cases := []struct {
+		ContentType string
+		Body        string
+		Expected    string
+	}{
+		{
+			"application/json",
+			`{"name":"test","spec":{"cloud":{"aws":{"accessKeyId":"key","vpcId":"vpc"}}}}`,
+			`{"name":"test","spec":{"cloud":{"aws":{"accessKeyId":"REDACTED","vpcId":"vpc"}}}}`,
+		},
+		{
+			"application/json; charset=utf-8",
+			`[{"id":"sa-token","token":"secret"}]`,
+			`[{"id":"sa-token","token":"REDACTED"}]`,
+		},
+		{
+			"application/x-www-form-urlencoded",
+			"client_id=kubermatic&grant_type=refresh_token&refresh_token=secret",
+			"client_id=kubermatic&grant_type=refresh_token&refresh_token=REDACTED",
+		},
+		{
+			"application/yaml",
+			"apiVersion: v1",
+			"(14 bytes of application/yaml)",
+		},
+		{
+			"application/json",
+			"",
+			"",
+		},
+	}
+
+	for _, tc := range cases {
+		if got := redactBody(tc.ContentType, []byte(tc.Body)); got != tc.Expected {
+			t.Fatalf("want redactBody(%q)=%q, got %q", tc.Body, tc.Expected, got)
+		}
+	}
+}
+
+func TestDebugTransport(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"test"}`))
+	}))
+	defer s.Close()
+
+	c := &http.Client{Transport: &debugTransport{next: http.DefaultTransport, log: zap.NewNop().Sugar()}}
+	resp, err := c.Post(s.URL, "application/json", strings.NewReader(`{"password":"secret"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	b, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"name":"test"}` {
+		t.Fatalf("want response body left readable, got %q", b)
+	}
+}
+
+func TestDebugTransportTokenExchange(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"issued-secret","issued_token_type":"urn:ietf:params:oauth:token-type:id_token"}`))
+	}))
+	defer s.Close()
+
+	core, logs := observer.New(zapcore.DebugLevel)
+	c := &http.Client{Transport: &debugTransport{next: http.DefaultTransport, log: zap.New(core).Sugar()}}
+	resp, err := c.PostForm(s.URL, url.Values{
+		"grant_type":    {tokenExchangeGrantType},
+		"subject_token": {"subject-secret"},
+		"client_id":     {"kubermatic"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if logs.Len() != 2 {
+		t.Fatalf("want request and response logged, got %d entries", logs.Len())
+	}
+	for _, e := range logs.All() {
+		for key, val := range e.ContextMap() {
+			v := fmt.Sprint(val)
+			if strings.Contains(v, "subject-secret") || strings.Contains(v, "issued-secret") {
+				t.Fatalf("want token redacted from %q log, got %s=%s", e.Message, key, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package kubermatic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// healthzPath is probed to check whether a Kubermatic API endpoint is up.
+const healthzPath = "/api/v1/healthz"
+
+// selectEndpoint returns the first endpoint responding to the health probe.
+// Any response not being a server error counts, as the endpoint is
+// reachable and serving.
+func selectEndpoint(c *http.Client, endpoints []string) (string, error) {
+	var failures []string
+	for _, e := range endpoints {
+		resp, err := c.Get(strings.TrimSuffix(e, "/") + healthzPath)
+		if err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", e, err))
+			continue
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			failures = append(failures, fmt.Sprintf("%s: health check responded with %s", e, resp.Status))
+			continue
+		}
+		return e, nil
+	}
+	return "", fmt.Errorf("no Kubermatic API endpoint is available, check provider host and endpoints:\n%s", strings.Join(failures, "\n"))
+}
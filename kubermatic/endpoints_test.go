@@ -0,0 +1,47 @@
+package kubermatic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestSelectEndpoint(t *testing.T) {
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+	up := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != healthzPath {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer up.Close()
+	closed := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	closed.Close()
+
+	cases := []struct {
+		Endpoints []string
+		Expected  string
+	}{
+		{[]string{up.URL, down.URL}, up.URL},
+		{[]string{down.URL, closed.URL, up.URL + "/"}, up.URL + "/"},
+		{[]string{down.URL, closed.URL}, ""},
+	}
+
+	for _, tc := range cases {
+		got, err := selectEndpoint(http.DefaultClient, tc.Endpoints)
+		if tc.Expected == "" {
+			if err == nil {
+				t.Fatalf("want error without available endpoint, got %q", got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.Expected {
+			t.Fatalf("want endpoint %q, got %q", tc.Expected, got)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package kubermatic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v2"
+)
+
+// kubermaticConfigurationsPath lists KubermaticConfiguration resources of
+// the operator, their ingress domain is the Kubermatic API host.
+const kubermaticConfigurationsPath = "/apis/operator.kubermatic.io/v1alpha1/kubermaticconfigurations"
+
+// masterKubeconfig is the master cluster endpoint and credentials of a
+// kubeconfig context.
+type masterKubeconfig struct {
+	server   string
+	insecure bool
+	// certificates are PEM encoded or file paths
+	caCertificate     string
+	clientCertificate string
+	clientKey         string
+	// token authenticates to the master cluster
+	token string
+	// idToken is the OIDC token of the user, the only credential usable
+	// with the Kubermatic API
+	idToken string
+}
+
+type kubeconfigFile struct {
+	CurrentContext string `yaml:"current-context"`
+	Contexts       []struct {
+		Name    string `yaml:"name"`
+		Context struct {
+			Cluster string `yaml:"cluster"`
+			User    string `yaml:"user"`
+		} `yaml:"context"`
+	} `yaml:"contexts"`
+	Clusters []struct {
+		Name    string `yaml:"name"`
+		Cluster struct {
+			Server                   string `yaml:"server"`
+			CertificateAuthority     string `yaml:"certificate-authority"`
+			CertificateAuthorityData string `yaml:"certificate-authority-data"`
+			InsecureSkipTLSVerify    bool   `yaml:"insecure-skip-tls-verify"`
+		} `yaml:"cluster"`
+	} `yaml:"clusters"`
+	Users []struct {
+		Name string `yaml:"name"`
+		User struct {
+			Token                 string `yaml:"token"`
+			TokenFile             string `yaml:"tokenFile"`
+			ClientCertificate     string `yaml:"client-certificate"`
+			ClientCertificateData string `yaml:"client-certificate-data"`
+			ClientKey             string `yaml:"client-key"`
+			ClientKeyData         string `yaml:"client-key-data"`
+			AuthProvider          struct {
+				Config struct {
+					IDToken string `yaml:"id-token"`
+				} `yaml:"config"`
+			} `yaml:"auth-provider"`
+		} `yaml:"user"`
+	} `yaml:"users"`
+}
+
+// loadMasterKubeconfig reads the context of the kubeconfig, the current
+// context if none is given.
+func loadMasterKubeconfig(path, context string) (*masterKubeconfig, error) {
+	p, err := homedir.Expand(path)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read master_kubeconfig_path: %v", err)
+	}
+	var cfg kubeconfigFile
+	if err := yaml.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("unable to parse master kubeconfig: %v", err)
+	}
+	return cfg.master(context)
+}
+
+func (cfg *kubeconfigFile) master(context string) (*masterKubeconfig, error) {
+	if context == "" {
+		context = cfg.CurrentContext
+	}
+
+	var clusterName, userName string
+	found := false
+	for _, c := range cfg.Contexts {
+		if c.Name == context {
+			clusterName, userName, found = c.Context.Cluster, c.Context.User, true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("context '%s' not found in master kubeconfig", context)
+	}
+
+	var m masterKubeconfig
+	for _, c := range cfg.Clusters {
+		if c.Name != clusterName {
+			continue
+		}
+		m.server = strings.TrimSuffix(c.Cluster.Server, "/")
+		m.insecure = c.Cluster.InsecureSkipTLSVerify
+		m.caCertificate = c.Cluster.CertificateAuthority
+		if c.Cluster.CertificateAuthorityData != "" {
+			ca, err := base64.StdEncoding.DecodeString(c.Cluster.CertificateAuthorityData)
+			if err != nil {
+				return nil, fmt.Errorf("invalid certificate-authority-data of cluster '%s': %v", clusterName, err)
+			}
+			m.caCertificate = string(ca)
+		}
+		break
+	}
+	if m.server == "" {
+		return nil, fmt.Errorf("cluster '%s' of context '%s' has no server", clusterName, context)
+	}
+
+	for _, u := range cfg.Users {
+		if u.Name != userName {
+			continue
+		}
+		m.idToken = u.User.AuthProvider.Config.IDToken
+		m.token = u.User.Token
+		if m.token == "" {
+			m.token = m.idToken
+		}
+		if m.token == "" && u.User.TokenFile != "" {
+			token, err := readToken(u.User.TokenFile)
+			if err != nil {
+				return nil, err
+			}
+			m.token = token
+		}
+
+		m.clientCertificate = u.User.ClientCertificate
+		m.clientKey = u.User.ClientKey
+		for _, v := range []struct {
+			data string
+			out  *string
+		}{
+			{u.User.ClientCertificateData, &m.clientCertificate},
+			{u.User.ClientKeyData, &m.clientKey},
+		} {
+			if v.data == "" {
+				continue
+			}
+			b, err := base64.StdEncoding.DecodeString(v.data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid client credentials of user '%s': %v", userName, err)
+			}
+			*v.out = string(b)
+		}
+		break
+	}
+
+	return &m, nil
+}
+
+// discoverHost returns the Kubermatic API host configured in the
+// KubermaticConfiguration of the master cluster.
+func discoverHost(c *http.Client, m *masterKubeconfig) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, m.server+kubermaticConfigurationsPath, nil)
+	if err != nil {
+		return "", err
+	}
+	if m.token != "" {
+		req.Header.Set("Authorization", "Bearer "+m.token)
+	}
+
+	resp, err := c.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to list KubermaticConfigurations of the master cluster: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to list KubermaticConfigurations of the master cluster: master responded with %s", resp.Status)
+	}
+
+	var list struct {
+		Items []struct {
+			Spec struct {
+				Ingress struct {
+					Domain string `json:"domain"`
+				} `json:"ingress"`
+			} `json:"spec"`
+		} `json:"items"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return "", fmt.Errorf("unable to decode KubermaticConfigurations: %v", err)
+	}
+	for _, item := range list.Items {
+		if item.Spec.Ingress.Domain != "" {
+			return "https://" + item.Spec.Ingress.Domain, nil
+		}
+	}
+	return "", fmt.Errorf("master cluster has no KubermaticConfiguration with an ingress domain")
+}
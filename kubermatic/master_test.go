@@ -0,0 +1,116 @@
+package kubermatic
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/yaml.v2"
+)
+
+const testMasterKubeconfig = `
+current-context: admin
+contexts:
+- name: admin
+  context:
+    cluster: master
+    user: admin
+- name: oidc
+  context:
+    cluster: master
+    user: oidc
+clusters:
+- name: master
+  cluster:
+    server: https://master.example.com:6443/
+    certificate-authority-data: %s
+users:
+- name: admin
+  user:
+    token: admin-token
+- name: oidc
+  user:
+    auth-provider:
+      config:
+        id-token: id-token
+`
+
+func TestMasterKubeconfig(t *testing.T) {
+	ca := base64.StdEncoding.EncodeToString([]byte("-----BEGIN CERTIFICATE-----"))
+	var cfg kubeconfigFile
+	if err := yaml.Unmarshal([]byte(fmt.Sprintf(testMasterKubeconfig, ca)), &cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Context  string
+		Expected *masterKubeconfig
+	}{
+		{
+			"",
+			&masterKubeconfig{
+				server:        "https://master.example.com:6443",
+				caCertificate: "-----BEGIN CERTIFICATE-----",
+				token:         "admin-token",
+			},
+		},
+		{
+			"oidc",
+			&masterKubeconfig{
+				server:        "https://master.example.com:6443",
+				caCertificate: "-----BEGIN CERTIFICATE-----",
+				token:         "id-token",
+				idToken:       "id-token",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := cfg.master(tc.Context)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.Expected, got, cmp.AllowUnexported(masterKubeconfig{})); diff != "" {
+			t.Fatalf("Unexpected master kubeconfig: mismatch (-want +got):\n%s", diff)
+		}
+	}
+
+	if _, err := cfg.master("missing"); err == nil {
+		t.Fatal("want error for missing context")
+	}
+}
+
+func TestDiscoverHost(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != kubermaticConfigurationsPath || r.Header.Get("Authorization") != "Bearer admin-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"items": []interface{}{
+				map[string]interface{}{
+					"spec": map[string]interface{}{
+						"ingress": map[string]interface{}{"domain": "kubermatic.example.com"},
+					},
+				},
+			},
+		})
+	}))
+	defer s.Close()
+
+	host, err := discoverHost(s.Client(), &masterKubeconfig{server: s.URL, token: "admin-token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://kubermatic.example.com"; host != want {
+		t.Fatalf("want host %q, got %q", want, host)
+	}
+
+	if _, err := discoverHost(s.Client(), &masterKubeconfig{server: s.URL}); err == nil {
+		t.Fatal("want error for forbidden request")
+	}
+}
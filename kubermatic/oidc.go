@@ -0,0 +1,148 @@
+package kubermatic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// oidcConfig configures obtaining tokens from an OIDC issuer with a
+// refresh token or by token exchange, as an alternative to a
+// pre-generated token.
+type oidcConfig struct {
+	issuerURL    string
+	clientID     string
+	clientSecret string
+	refreshToken string
+	// subjectTokenPath is exchanged for ID tokens instead of refreshToken
+	subjectTokenPath string
+	connectorID      string
+}
+
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	idTokenType            = "urn:ietf:params:oauth:token-type:id_token"
+)
+
+type oidcTokenResponse struct {
+	IDToken         string `json:"id_token"`
+	RefreshToken    string `json:"refresh_token"`
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+}
+
+// newOIDCTokenRefresh returns ID tokens obtained with the refresh token
+// grant. Issuers rotating refresh tokens return a new one with every ID
+// token, it replaces the configured one.
+func newOIDCTokenRefresh(c *http.Client, cfg *oidcConfig) func(string) (string, error) {
+	refreshToken := cfg.refreshToken
+	return func(string) (string, error) {
+		form := url.Values{
+			"grant_type":    {"refresh_token"},
+			"refresh_token": {refreshToken},
+			"client_id":     {cfg.clientID},
+			"scope":         {"openid email"},
+		}
+		if cfg.clientSecret != "" {
+			form.Set("client_secret", cfg.clientSecret)
+		}
+
+		t, err := requestOIDCToken(c, cfg.issuerURL, form)
+		if err != nil {
+			return "", err
+		}
+		if t.RefreshToken != "" {
+			refreshToken = t.RefreshToken
+		}
+		return t.IDToken, nil
+	}
+}
+
+// newOIDCTokenExchange returns ID tokens obtained by exchanging the token
+// read from the subject token file, e.g. a projected Kubernetes service
+// account token, with the token exchange grant. The file is read on every
+// exchange as the kubelet rotates projected tokens.
+func newOIDCTokenExchange(c *http.Client, cfg *oidcConfig) func(string) (string, error) {
+	return func(string) (string, error) {
+		subjectToken, err := readToken(cfg.subjectTokenPath)
+		if err != nil {
+			return "", err
+		}
+
+		form := url.Values{
+			"grant_type":           {tokenExchangeGrantType},
+			"subject_token":        {subjectToken},
+			"subject_token_type":   {idTokenType},
+			"requested_token_type": {idTokenType},
+			"client_id":            {cfg.clientID},
+			"scope":                {"openid email"},
+		}
+		if cfg.clientSecret != "" {
+			form.Set("client_secret", cfg.clientSecret)
+		}
+		if cfg.connectorID != "" {
+			form.Set("connector_id", cfg.connectorID)
+		}
+
+		t, err := requestOIDCToken(c, cfg.issuerURL, form)
+		if err != nil {
+			return "", err
+		}
+		return t.IDToken, nil
+	}
+}
+
+// requestOIDCToken posts the grant to the issuer token endpoint. Token
+// exchange responses carry the ID token in access_token.
+func requestOIDCToken(c *http.Client, issuerURL string, form url.Values) (*oidcTokenResponse, error) {
+	endpoint, err := oidcTokenEndpoint(c, issuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.PostForm(endpoint, form)
+	if err != nil {
+		return nil, fmt.Errorf("request OIDC token: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request OIDC token: issuer responded with %s", resp.Status)
+	}
+
+	var t oidcTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&t); err != nil {
+		return nil, fmt.Errorf("decode OIDC token response: %v", err)
+	}
+	if t.IDToken == "" && t.IssuedTokenType == idTokenType {
+		t.IDToken = t.AccessToken
+	}
+	if t.IDToken == "" {
+		return nil, fmt.Errorf("OIDC token response has no id_token")
+	}
+	return &t, nil
+}
+
+// oidcTokenEndpoint reads the token endpoint from the issuer discovery document.
+func oidcTokenEndpoint(c *http.Client, issuerURL string) (string, error) {
+	resp, err := c.Get(strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration")
+	if err != nil {
+		return "", fmt.Errorf("discover OIDC issuer: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("discover OIDC issuer: issuer responded with %s", resp.Status)
+	}
+
+	var doc struct {
+		TokenEndpoint string `json:"token_endpoint"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return "", fmt.Errorf("decode OIDC discovery document: %v", err)
+	}
+	if doc.TokenEndpoint == "" {
+		return "", fmt.Errorf("OIDC issuer '%s' has no token endpoint", issuerURL)
+	}
+	return doc.TokenEndpoint, nil
+}
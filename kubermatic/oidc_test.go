@@ -0,0 +1,98 @@
+package kubermatic
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestOIDCTokenRefresh(t *testing.T) {
+	var s *httptest.Server
+	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{"token_endpoint": s.URL + "/token"})
+		case "/token":
+			r.ParseForm()
+			if r.Form.Get("grant_type") != "refresh_token" || r.Form.Get("client_id") != "kubermatic" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			switch r.Form.Get("refresh_token") {
+			case "refresh-1":
+				json.NewEncoder(w).Encode(oidcTokenResponse{IDToken: "id-1", RefreshToken: "refresh-2"})
+			case "refresh-2":
+				json.NewEncoder(w).Encode(oidcTokenResponse{IDToken: "id-2"})
+			default:
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	refresh := newOIDCTokenRefresh(s.Client(), &oidcConfig{
+		issuerURL:    s.URL,
+		clientID:     "kubermatic",
+		refreshToken: "refresh-1",
+	})
+
+	for _, want := range []string{"id-1", "id-2", "id-2"} {
+		got, err := refresh("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("want token %q, got %q", want, got)
+		}
+	}
+}
+
+func TestOIDCTokenExchange(t *testing.T) {
+	var s *httptest.Server
+	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/.well-known/openid-configuration":
+			json.NewEncoder(w).Encode(map[string]string{"token_endpoint": s.URL + "/token"})
+		case "/token":
+			r.ParseForm()
+			if r.Form.Get("grant_type") != tokenExchangeGrantType || r.Form.Get("connector_id") != "kubernetes" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			json.NewEncoder(w).Encode(oidcTokenResponse{
+				AccessToken:     "id-" + r.Form.Get("subject_token"),
+				IssuedTokenType: idTokenType,
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer s.Close()
+
+	path := filepath.Join(t.TempDir(), "token")
+	refresh := newOIDCTokenExchange(s.Client(), &oidcConfig{
+		issuerURL:        s.URL,
+		clientID:         "kubermatic",
+		subjectTokenPath: path,
+		connectorID:      "kubernetes",
+	})
+
+	// projected tokens are rotated by the kubelet
+	for _, sa := range []string{"sa-1", "sa-2"} {
+		if err := ioutil.WriteFile(path, []byte(sa+"\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := refresh("")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := "id-" + sa; got != want {
+			t.Fatalf("want token %q, got %q", want, got)
+		}
+	}
+}
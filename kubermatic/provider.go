@@ -2,34 +2,73 @@ package kubermatic
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-openapi/runtime"
 	oclient "github.com/go-openapi/runtime/client"
+	"github.com/go-openapi/strfmt"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	k8client "github.com/kubermatic/go-kubermatic/client"
+	"github.com/kubermatic/go-kubermatic/client/users"
 	"github.com/mitchellh/go-homedir"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
 const (
-	// wait this time before starting resource checks
-	requestDelay = time.Second
 	// smallest time to wait before refreshes
 	retryTimeout = time.Second
+	// default timeout of resource operations
+	defaultResourceTimeout = 20 * time.Minute
+	// default timeout of API requests
+	defaultRequestTimeout = 30 * time.Second
+	// token file read when no token is configured
+	defaultTokenPath = "~/.kubermatic/auth"
+	// host used when no host is configured or discovered
+	defaultHost = "https://localhost"
 )
 
 type kubermaticProviderMeta struct {
 	client *k8client.Kubermatic
 	auth   runtime.ClientAuthInfoWriter
 	log    *zap.SugaredLogger
+	// timeouts are provider level defaults of resource operation timeouts
+	timeouts map[string]time.Duration
+
+	// retry policy of resource operations and waiters
+	maxRetries    int
+	retryMinDelay time.Duration
+	retryMaxDelay time.Duration
+
+	// defaultProjectID is project of resources not setting project_id
+	defaultProjectID string
+
+	// defaultLabels are added to labels of created projects, clusters
+	// and node deployments
+	defaultLabels map[string]string
+
+	// limiter is shared by all API clients of the provider, nil if
+	// requests are not limited
+	limiter *rateLimiter
+
+	// seeds caches seeds of data centers
+	seedsMu sync.Mutex
+	seeds   map[string]string
 }
 
 // Provider is a Kubermatic Terraform Provider.
@@ -39,24 +78,203 @@ func Provider() terraform.ResourceProvider {
 			"host": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_HOST", "https://localhost"),
-				Description: "The Kubermatic hostname",
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_HOST", defaultHost),
+				Description: "The Kubermatic hostname, discovered from master_kubeconfig_path if not set",
+			},
+			"master_kubeconfig_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_MASTER_KUBECONFIG", ""),
+				Description: "Kubeconfig of the master cluster to discover the host from KubermaticConfiguration, its auth-provider id-token is used if no other token is found",
+			},
+			"master_kubeconfig_context": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_MASTER_KUBECONFIG_CONTEXT", ""),
+				Description: "Context of master_kubeconfig_path, current context if not set",
+			},
+			"endpoints": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Failover Kubermatic hostnames, the first of host and endpoints passing the health check is used",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.NoZeroValues,
+				},
 			},
 			"token": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_TOKEN", ""),
-				Description: "Authorization token",
+				Sensitive:   true,
+				Description: "Authorization token, takes precedence over token_path and KUBERMATIC_TOKEN environment variable",
 			},
 			"token_path": {
-				Type:     schema.TypeString,
-				Optional: true,
-				DefaultFunc: schema.MultiEnvDefaultFunc(
-					[]string{
-						"KUBERMATIC_TOKEN_PATH",
-					},
-					"~/.kubermatic/auth"),
-				Description: "Path to the Kubermatic authorization token, defaults to ~/.kubermatic/auth",
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_TOKEN_PATH", ""),
+				Description: "Path to the Kubermatic authorization token, takes precedence over KUBERMATIC_TOKEN environment variable, " + defaultTokenPath + " is read if no token is set",
+			},
+			"token_auto_refresh": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_TOKEN_AUTO_REFRESH", false),
+				Description: "Regenerate the service account token through the tokens API when it is about to expire. Regenerating revokes the configured token, the token has to be read from a writable token_path which the new token is written to",
+			},
+			"ca_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_CA_CERTIFICATE", ""),
+				Description: "PEM encoded CA certificate or path to it, used to verify the Kubermatic API certificate in addition to system CAs",
+			},
+			"client_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_CLIENT_CERTIFICATE", ""),
+				Description: "PEM encoded client certificate or path to it, presented to API gateways requiring mutual TLS",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_CLIENT_KEY", ""),
+				Description: "PEM encoded private key of client_certificate or path to it",
+			},
+			"insecure_skip_tls_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_INSECURE_SKIP_TLS_VERIFY", false),
+				Description: "Skip verification of the Kubermatic API certificate, insecure",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_PROXY_URL", ""),
+				Description: "Proxy for API requests, HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are used if not set",
+			},
+			"oidc_issuer_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_OIDC_ISSUER_URL", ""),
+				Description: "OIDC issuer URL to obtain tokens from using oidc_refresh_token instead of a configured token",
+			},
+			"oidc_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_OIDC_CLIENT_ID", ""),
+				Description: "OIDC client identifier",
+			},
+			"oidc_client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_OIDC_CLIENT_SECRET", ""),
+				Description: "OIDC client secret, required by confidential clients",
+			},
+			"oidc_refresh_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_OIDC_REFRESH_TOKEN", ""),
+				Description: "OIDC refresh token used to obtain and refresh ID tokens",
+			},
+			"oidc_subject_token_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_OIDC_SUBJECT_TOKEN_PATH", ""),
+				Description: "Path to a token exchanged for ID tokens instead of using oidc_refresh_token, e.g. a projected Kubernetes service account token",
+			},
+			"oidc_connector_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_OIDC_CONNECTOR_ID", ""),
+				Description: "Dex connector trusting the oidc_subject_token_path issuer",
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_SKIP_CREDENTIALS_VALIDATION", false),
+				Description: "Skip validating credentials against the current user API when the provider is configured",
+			},
+			"default_project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_PROJECT_ID", ""),
+				Description: "Project of clusters, node deployments and SSH keys not setting project_id",
+			},
+			"default_labels": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateLabels,
+				Description:  "Labels of projects, clusters and node deployments, labels set by resources take precedence",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"default_create_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  "Create timeout of resources not setting their own, e.g. 30m. A resource timeout set to the resource default of 20m can't be told apart from an unset one and is replaced by this timeout too",
+			},
+			"default_update_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  "Update timeout of resources not setting their own, e.g. 30m. A resource timeout set to the resource default of 20m can't be told apart from an unset one and is replaced by this timeout too",
+			},
+			"default_delete_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  "Delete timeout of resources not setting their own, e.g. 30m. A resource timeout set to the resource default of 20m can't be told apart from an unset one and is replaced by this timeout too",
+			},
+			"request_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultRequestTimeout.String(),
+				ValidateFunc: validateDuration,
+				Description:  "Timeout of every single API request",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries of transient API errors, waiting for pending states is limited by timeouts only, unlimited if 0",
+			},
+			"retry_min_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultRetryMinDelay.String(),
+				ValidateFunc: validateDuration,
+				Description:  "Initial delay between retries, it doubles with every retry",
+			},
+			"retry_max_delay": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      defaultRetryMaxDelay.String(),
+				ValidateFunc: validateDuration,
+				Description:  "Maximum delay between retries",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "Maximum rate of API requests, not limited if 0",
+			},
+			"burst": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of API requests sent at once within requests_per_second, defaults to requests_per_second rounded up",
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_USER_AGENT_SUFFIX", ""),
+				Description: "Appended to the User-Agent header of API requests identifying Terraform and the provider version",
 			},
 			"development": {
 				Type:        schema.TypeBool,
@@ -70,6 +288,12 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_DEBUG", false),
 				Description: "Run debug mode.",
 			},
+			"debug_api_calls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("KUBERMATIC_DEBUG_API_CALLS", false),
+				Description: "Log API requests and responses with secrets redacted at debug level, enables debug mode",
+			},
 			"log_path": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -79,10 +303,28 @@ func Provider() terraform.ResourceProvider {
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"kubermatic_project":         resourceProject(),
-			"kubermatic_cluster":         resourceCluster(),
-			"kubermatic_node_deployment": resourceNodeDeployment(),
-			"kubermatic_sshkey":          resourceSSHKey(),
+			"kubermatic_project":               resourceProject(),
+			"kubermatic_project_user":          resourceProjectUser(),
+			"kubermatic_cluster":               resourceCluster(),
+			"kubermatic_node_deployment":       resourceNodeDeployment(),
+			"kubermatic_sshkey":                resourceSSHKey(),
+			"kubermatic_service_account":       resourceServiceAccount(),
+			"kubermatic_service_account_token": resourceServiceAccountToken(),
+			"kubermatic_admin_settings":        resourceAdminSettings(),
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"kubermatic_cluster_kubeconfig":        dataSourceClusterKubeconfig(),
+			"kubermatic_cluster_viewer_kubeconfig": dataSourceClusterViewerKubeconfig(),
+			"kubermatic_cluster_nodes":             dataSourceClusterNodes(),
+			"kubermatic_cluster_spec":              dataSourceClusterSpec(),
+			"kubermatic_preset":                    dataSourcePreset(),
+			"kubermatic_datacenters":               dataSourceDatacenters(),
+			"kubermatic_operating_systems":         dataSourceOperatingSystems(),
+			"kubermatic_api_health":                dataSourceAPIHealth(),
+			"kubermatic_features":                  dataSourceFeatures(),
+			"kubermatic_project":                   dataSourceProject(),
+			"kubermatic_projects":                  dataSourceProjects(),
 		},
 	}
 
@@ -106,34 +348,177 @@ func Provider() terraform.ResourceProvider {
 func configure(d *schema.ResourceData, terraformVersion string, fd *os.File) (interface{}, error) {
 	logDev := d.Get("development").(bool)
 	logDebug := d.Get("debug").(bool)
+	debugAPICalls := d.Get("debug_api_calls").(bool)
 	logPath := d.Get("log_path").(string)
 	host := d.Get("host").(string)
 	token := d.Get("token").(string)
 	tokenPath := d.Get("token_path").(string)
-	return newKubermaticProviderMeta(logDev, logDebug, logPath, host, token, tokenPath, fd)
+	tokenAutoRefresh := d.Get("token_auto_refresh").(bool)
+
+	var oidc *oidcConfig
+	if v, ok := d.GetOk("oidc_issuer_url"); ok {
+		oidc = &oidcConfig{
+			issuerURL:    v.(string),
+			clientID:     d.Get("oidc_client_id").(string),
+			clientSecret: d.Get("oidc_client_secret").(string),
+			refreshToken: d.Get("oidc_refresh_token").(string),
+
+			subjectTokenPath: d.Get("oidc_subject_token_path").(string),
+			connectorID:      d.Get("oidc_connector_id").(string),
+		}
+		if oidc.clientID == "" || (oidc.refreshToken == "" && oidc.subjectTokenPath == "") {
+			return nil, fmt.Errorf("oidc_client_id and oidc_refresh_token or oidc_subject_token_path are required with oidc_issuer_url")
+		}
+	}
+
+	httpTransport, err := newHTTPTransport(
+		d.Get("ca_certificate").(string),
+		d.Get("insecure_skip_tls_verify").(bool),
+		d.Get("proxy_url").(string),
+		d.Get("client_certificate").(string),
+		d.Get("client_key").(string),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var tr http.RoundTripper = &userAgentTransport{
+		next:      httpTransport,
+		userAgent: userAgent(terraformVersion, d.Get("user_agent_suffix").(string)),
+	}
+	limiter := newRateLimiter(d.Get("requests_per_second").(float64), d.Get("burst").(int))
+	if limiter != nil {
+		tr = &rateLimitTransport{next: tr, limiter: limiter}
+	}
+
+	// already validated
+	requestTimeout, _ := time.ParseDuration(d.Get("request_timeout").(string))
+
+	var masterToken string
+	if path := d.Get("master_kubeconfig_path").(string); path != "" {
+		master, err := loadMasterKubeconfig(path, d.Get("master_kubeconfig_context").(string))
+		if err != nil {
+			return nil, err
+		}
+		if host == defaultHost {
+			masterTransport, err := newHTTPTransport(master.caCertificate, master.insecure, d.Get("proxy_url").(string), master.clientCertificate, master.clientKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid master kubeconfig: %v", err)
+			}
+			host, err = discoverHost(&http.Client{Transport: masterTransport, Timeout: requestTimeout}, master)
+			if err != nil {
+				return nil, err
+			}
+		}
+		// OIDC tokens of the master are accepted if issued by the Kubermatic
+		// issuer, other master credentials must not leave the master
+		masterToken = master.idToken
+	}
+
+	endpoints := []string{host}
+	for _, e := range d.Get("endpoints").([]interface{}) {
+		endpoints = append(endpoints, e.(string))
+	}
+	host, err = selectEndpoint(&http.Client{Transport: tr, Timeout: requestTimeout}, endpoints)
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := newKubermaticProviderMeta(logDev, logDebug, debugAPICalls, logPath, host, token, tokenPath, masterToken, tokenAutoRefresh, oidc, tr, requestTimeout, fd)
+	if err != nil {
+		return nil, err
+	}
+
+	if !d.Get("skip_credentials_validation").(bool) {
+		if _, err := k.client.Users.GetCurrentUser(users.NewGetCurrentUserParams(), k.auth); err != nil {
+			return nil, fmt.Errorf("unable to validate provider credentials: %s", getErrorResponse(err))
+		}
+	}
+
+	k.defaultProjectID = d.Get("default_project_id").(string)
+	k.defaultLabels = make(map[string]string)
+	for key, val := range d.Get("default_labels").(map[string]interface{}) {
+		k.defaultLabels[key] = val.(string)
+	}
+	k.limiter = limiter
+	k.maxRetries = d.Get("max_retries").(int)
+	// already validated
+	k.retryMinDelay, _ = time.ParseDuration(d.Get("retry_min_delay").(string))
+	k.retryMaxDelay, _ = time.ParseDuration(d.Get("retry_max_delay").(string))
+
+	k.timeouts = make(map[string]time.Duration)
+	for key, attr := range map[string]string{
+		schema.TimeoutCreate: "default_create_timeout",
+		schema.TimeoutUpdate: "default_update_timeout",
+		schema.TimeoutDelete: "default_delete_timeout",
+	} {
+		if v, ok := d.GetOk(attr); ok {
+			// already validated
+			k.timeouts[key], _ = time.ParseDuration(v.(string))
+		}
+	}
+
+	return k, nil
 }
 
-func newKubermaticProviderMeta(logDev, logDebug bool, logPath, host, token, tokenPath string, fd *os.File) (*kubermaticProviderMeta, error) {
+func newKubermaticProviderMeta(logDev, logDebug, debugAPICalls bool, logPath, host, token, tokenPath, masterToken string, tokenAutoRefresh bool, oidc *oidcConfig, tr http.RoundTripper, requestTimeout time.Duration, fd *os.File) (*kubermaticProviderMeta, error) {
 	var (
 		k   kubermaticProviderMeta
 		err error
 	)
 
-	k.log, err = newLogger(logDev, logDebug, logPath, fd)
+	k.log, err = newLogger(logDev, logDebug || debugAPICalls, logPath, fd)
 	if err != nil {
 		return nil, err
 	}
 
-	k.client, err = newClient(host)
-	if err != nil {
-		return nil, err
+	if debugAPICalls {
+		if tr == nil {
+			tr = http.DefaultTransport
+		}
+		tr = &debugTransport{next: tr, log: k.log}
+	}
+
+	var auth *tokenAuth
+	if oidc != nil {
+		c := &http.Client{Transport: tr, Timeout: requestTimeout}
+		refresh := newOIDCTokenRefresh(c, oidc)
+		if oidc.subjectTokenPath != "" {
+			refresh = newOIDCTokenExchange(c, oidc)
+		}
+		token, err := refresh("")
+		if err != nil {
+			return nil, err
+		}
+		auth = &tokenAuth{token: token, refresh: refresh, log: k.log}
+	} else {
+		auth, err = newAuth(token, tokenPath, masterToken)
+		if err != nil {
+			return nil, err
+		}
 	}
+	k.auth = auth
 
-	k.auth, err = newAuth(token, tokenPath)
+	k.client, err = newClient(host, auth, tr, requestTimeout)
 	if err != nil {
 		return nil, err
 	}
 
+	if tokenAutoRefresh && oidc == nil {
+		path, err := refreshTokenPath(token, tokenPath, os.Getenv("KUBERMATIC_TOKEN"))
+		if err != nil {
+			return nil, err
+		}
+		// tokens API is called with the expiring token, its requests must
+		// not trigger another refresh
+		refreshClient, err := newClient(host, nil, tr, requestTimeout)
+		if err != nil {
+			return nil, err
+		}
+		auth.refresh = newServiceAccountTokenRefresh(refreshClient)
+		auth.path = path
+		auth.log = k.log
+	}
+
 	return &k, nil
 }
 
@@ -175,42 +560,286 @@ func newLogger(logDev, logDebug bool, logPath string, fd *os.File) (*zap.Sugared
 	return zap.New(core).Sugar(), nil
 }
 
-func newClient(host string) (*k8client.Kubermatic, error) {
+// newClient returns API client, auth is used to retry unauthorized requests
+// with a regenerated token, nil disables retries. Requests are sent
+// through tr, or default transport if nil.
+func newClient(host string, auth *tokenAuth, tr http.RoundTripper, requestTimeout time.Duration) (*k8client.Kubermatic, error) {
 	u, err := url.Parse(host)
 	if err != nil {
 		return nil, err
 	}
 
-	return k8client.NewHTTPClientWithConfig(nil, &k8client.TransportConfig{
-		Host:     u.Host,
-		BasePath: u.Path,
-		Schemes:  []string{u.Scheme},
-	}), nil
+	transport := oclient.New(u.Host, u.Path, []string{u.Scheme})
+	if tr != nil {
+		transport.Transport = tr
+	}
+	transport.Transport = &authTransport{next: transport.Transport, auth: auth}
+	// kubeconfig endpoints respond with YAML, which is read as raw bytes
+	transport.Consumers[yamlMime] = runtime.ByteStreamConsumer()
+
+	return k8client.New(&timeoutTransport{ClientTransport: transport, timeout: requestTimeout}, nil), nil
+}
+
+// timeoutTransport submits API calls with the request timeout, parameters
+// of the generated client default to the timeout of the runtime package.
+type timeoutTransport struct {
+	runtime.ClientTransport
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) Submit(op *runtime.ClientOperation) (interface{}, error) {
+	params := op.Params
+	op.Params = runtime.ClientRequestWriterFunc(func(r runtime.ClientRequest, reg strfmt.Registry) error {
+		if err := params.WriteToRequest(r, reg); err != nil {
+			return err
+		}
+		return r.SetTimeout(t.timeout)
+	})
+	return t.ClientTransport.Submit(op)
 }
 
-func newAuth(token, tokenPath string) (runtime.ClientAuthInfoWriter, error) {
-	if token == "" && tokenPath != "" {
-		p, err := homedir.Expand(tokenPath)
+// newHTTPTransport returns transport trusting the CA certificate in
+// addition to system CAs, presenting the client certificate if set.
+// Requests go through the proxy, or the proxy from environment if not set.
+func newHTTPTransport(caCertificate string, insecure bool, proxyURL, clientCertificate, clientKey string) (*http.Transport, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = &tls.Config{
+		InsecureSkipVerify: insecure,
+	}
+
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %v", err)
+		}
+		tr.Proxy = http.ProxyURL(u)
+	}
+
+	if caCertificate != "" {
+		pem, err := readPEM("ca_certificate", caCertificate)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("ca_certificate has no valid PEM encoded certificate")
+		}
+		tr.TLSClientConfig.RootCAs = pool
+	}
+
+	if clientCertificate != "" || clientKey != "" {
+		if clientCertificate == "" || clientKey == "" {
+			return nil, fmt.Errorf("client_certificate and client_key must be set together")
+		}
+		certPEM, err := readPEM("client_certificate", clientCertificate)
 		if err != nil {
 			return nil, err
 		}
-		rawToken, err := ioutil.ReadFile(p)
+		keyPEM, err := readPEM("client_key", clientKey)
 		if err != nil {
 			return nil, err
 		}
-		token = string(bytes.Trim(rawToken, "\n"))
-	} else if token == "" {
-		return nil, fmt.Errorf("Missing authorization token")
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client_certificate or client_key: %v", err)
+		}
+		tr.TLSClientConfig.Certificates = []tls.Certificate{cert}
 	}
 
-	return oclient.BearerToken(token), nil
+	return tr, nil
+}
+
+// readPEM returns PEM encoded value, or content of the file the value
+// points to.
+func readPEM(attr, value string) ([]byte, error) {
+	if strings.HasPrefix(strings.TrimSpace(value), "-----BEGIN") {
+		return []byte(value), nil
+	}
+	p, err := homedir.Expand(value)
+	if err != nil {
+		return nil, err
+	}
+	pem, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %s: %v", attr, err)
+	}
+	return pem, nil
+}
+
+// newAuth authenticates with the resolved token, the master token is used
+// only if no token is configured.
+func newAuth(token, tokenPath, masterToken string) (*tokenAuth, error) {
+	token, err := resolveToken(token, tokenPath, os.Getenv("KUBERMATIC_TOKEN"))
+	if errors.Is(err, errMissingToken) && masterToken != "" {
+		return &tokenAuth{token: masterToken}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tokenAuth{token: token}, nil
+}
+
+var errMissingToken = errors.New("missing authorization token, set provider token or token_path, or KUBERMATIC_TOKEN environment variable")
+
+// resolveToken picks the token from explicit value, token file, environment,
+// and default token file, in this order.
+func resolveToken(token, tokenPath, envToken string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+	if tokenPath != "" {
+		return readToken(tokenPath)
+	}
+	if envToken != "" {
+		return envToken, nil
+	}
+	if p, err := homedir.Expand(defaultTokenPath); err == nil {
+		if _, err := os.Stat(p); err == nil {
+			return readToken(p)
+		}
+	}
+	return "", errMissingToken
+}
+
+func readToken(path string) (string, error) {
+	p, err := homedir.Expand(path)
+	if err != nil {
+		return "", err
+	}
+	rawToken, err := ioutil.ReadFile(p)
+	if err != nil {
+		return "", fmt.Errorf("unable to read token_path: %v", err)
+	}
+	token := string(bytes.TrimSpace(rawToken))
+	if token == "" {
+		return "", fmt.Errorf("token file '%s' is empty", path)
+	}
+	return token, nil
+}
+
+// refreshTokenPath returns the token file regenerated tokens are written
+// to. Regenerating revokes the configured token, so refreshing is refused
+// unless the token is read from a writable file.
+func refreshTokenPath(token, tokenPath, envToken string) (string, error) {
+	path := tokenPath
+	if path == "" && envToken == "" {
+		path = defaultTokenPath
+	}
+	if token != "" || path == "" {
+		return "", fmt.Errorf("token_auto_refresh requires the token to be read from token_path, regenerating the token revokes the configured one")
+	}
+	p, err := homedir.Expand(path)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY, 0)
+	if err != nil {
+		return "", fmt.Errorf("token_auto_refresh requires a writable token_path: %v", err)
+	}
+	f.Close()
+	return p, nil
+}
+
+// writeToken replaces the token file, the file is written next to it first
+// so a failed write doesn't lose the token.
+func writeToken(path, token string) error {
+	f, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(token + "\n"); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+func validateDuration(v interface{}, k string) (strings []string, errors []error) {
+	if _, err := time.ParseDuration(v.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%s: invalid duration: %v", k, err))
+	}
+	return
+}
+
+// getTimeout returns timeout of the resource operation. Provider level
+// default is used unless the resource's timeouts block sets a value
+// different from the resource default. The SDK fills unset timeouts with
+// the resource defaults and doesn't expose the config to operations, so a
+// timeouts block setting the resource default is overridden as well.
+func getTimeout(d *schema.ResourceData, k *kubermaticProviderMeta, key string) time.Duration {
+	t := d.Timeout(key)
+	if v, ok := k.timeouts[key]; ok && t == defaultResourceTimeout {
+		return v
+	}
+	return t
+}
+
+// getProjectID returns project_id of the resource, or provider default
+// project if not set. The default is stored in the state, so following
+// operations read it from project_id.
+func getProjectID(d *schema.ResourceData, k *kubermaticProviderMeta) (string, error) {
+	if v, ok := d.GetOk("project_id"); ok {
+		return v.(string), nil
+	}
+	if k.defaultProjectID == "" {
+		return "", fmt.Errorf("project_id is not set and provider default_project_id is not configured")
+	}
+	d.Set("project_id", k.defaultProjectID)
+	return k.defaultProjectID, nil
+}
+
+// withDefaultLabels returns labels with provider default labels added,
+// labels take precedence over defaults.
+func withDefaultLabels(k *kubermaticProviderMeta, labels map[string]string) map[string]string {
+	if len(k.defaultLabels) == 0 {
+		return labels
+	}
+	out := make(map[string]string, len(labels)+len(k.defaultLabels))
+	for key, val := range k.defaultLabels {
+		out[key] = val
+	}
+	for key, val := range labels {
+		out[key] = val
+	}
+	return out
+}
+
+// excludeDefaultLabels removes provider default labels not set by the
+// resource, so they don't show up as diff. Default labels changed outside
+// of Terraform are kept and restored on update.
+func excludeDefaultLabels(k *kubermaticProviderMeta, labels map[string]string, configured map[string]interface{}) map[string]string {
+	for key, val := range k.defaultLabels {
+		if _, ok := configured[key]; !ok && labels[key] == val {
+			delete(labels, key)
+		}
+	}
+	return labels
 }
 
 // getErrorResponse converts the client error response to string
 func getErrorResponse(err error) string {
+	var ae *authError
+	if errors.As(err, &ae) {
+		return ae.Error()
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return "Kubermatic API did not respond within request_timeout: " + err.Error()
+	}
 	rawData, newErr := json.Marshal(err)
 	if newErr != nil {
 		return err.Error()
 	}
+	// error payloads may echo the request
+	if out, err := redactJSON(rawData); err == nil {
+		return out
+	}
 	return string(rawData)
 }
@@ -1,14 +1,31 @@
 package kubermatic
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/kubermatic/go-kubermatic/client/users"
+	"go.uber.org/zap"
 )
 
 const (
@@ -78,3 +95,365 @@ func testResourceInstanceState(name string, check func(*terraform.InstanceState)
 
 	}
 }
+
+func TestProvider(t *testing.T) {
+	if err := Provider().(*schema.Provider).InternalValidate(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetTimeout(t *testing.T) {
+	r := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+	}
+	k := &kubermaticProviderMeta{
+		timeouts: map[string]time.Duration{
+			schema.TimeoutCreate: time.Hour,
+		},
+	}
+
+	d := r.TestResourceData()
+	if got := getTimeout(d, k, schema.TimeoutCreate); got != time.Hour {
+		t.Fatalf("want provider default timeout %s, got %s", time.Hour, got)
+	}
+
+	k.timeouts = map[string]time.Duration{}
+	if got := getTimeout(d, k, schema.TimeoutCreate); got != defaultResourceTimeout {
+		t.Fatalf("want resource default timeout %s, got %s", defaultResourceTimeout, got)
+	}
+}
+
+func TestGetProjectID(t *testing.T) {
+	r := resourceSSHKey()
+	k := &kubermaticProviderMeta{}
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{"project_id": "abcdef"})
+	if got, err := getProjectID(d, k); err != nil || got != "abcdef" {
+		t.Fatalf("want resource project 'abcdef', got %q, %v", got, err)
+	}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{})
+	if _, err := getProjectID(d, k); err == nil {
+		t.Fatalf("want error without project_id and default_project_id")
+	}
+
+	k.defaultProjectID = "ghijkl"
+	if got, err := getProjectID(d, k); err != nil || got != "ghijkl" {
+		t.Fatalf("want default project 'ghijkl', got %q, %v", got, err)
+	}
+	if got := d.Get("project_id").(string); got != "ghijkl" {
+		t.Fatalf("want default project stored in project_id, got %q", got)
+	}
+}
+
+func TestDefaultLabels(t *testing.T) {
+	k := &kubermaticProviderMeta{
+		defaultLabels: map[string]string{
+			"team":        "platform",
+			"cost-center": "1234",
+		},
+	}
+
+	got := withDefaultLabels(k, map[string]string{"team": "data", "app": "etl"})
+	want := map[string]string{"team": "data", "cost-center": "1234", "app": "etl"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Unexpected labels with defaults: mismatch (-want +got):\n%s", diff)
+	}
+
+	got = excludeDefaultLabels(k, map[string]string{
+		"team":        "platform",
+		"cost-center": "5678",
+		"app":         "etl",
+	}, map[string]interface{}{"team": "platform", "app": "etl"})
+	want = map[string]string{"team": "platform", "cost-center": "5678", "app": "etl"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("Unexpected labels without defaults: mismatch (-want +got):\n%s", diff)
+	}
+
+	got = excludeDefaultLabels(k, map[string]string{"team": "platform", "cost-center": "1234"}, map[string]interface{}{})
+	if len(got) != 0 {
+		t.Fatalf("want default labels excluded, got %v", got)
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kubermatic-token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokenPath := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenPath, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Token     string
+		TokenPath string
+		EnvToken  string
+		Expected  string
+	}{
+		{"explicit-token", tokenPath, "env-token", "explicit-token"},
+		{"", tokenPath, "env-token", "file-token"},
+		{"", "", "env-token", "env-token"},
+	}
+
+	for _, tc := range cases {
+		got, err := resolveToken(tc.Token, tc.TokenPath, tc.EnvToken)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.Expected {
+			t.Fatalf("want token %q, got %q", tc.Expected, got)
+		}
+	}
+
+	if _, err := resolveToken("", filepath.Join(dir, "missing"), "env-token"); err == nil {
+		t.Fatalf("want error for missing token file")
+	}
+}
+
+func TestNewAuthMasterToken(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBERMATIC_TOKEN", "")
+
+	cases := []struct {
+		Token    string
+		Expected string
+	}{
+		{"explicit-token", "explicit-token"},
+		{"", "master-id-token"},
+	}
+
+	for _, tc := range cases {
+		auth, err := newAuth(tc.Token, "", "master-id-token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if auth.token != tc.Expected {
+			t.Fatalf("want token %q, got %q", tc.Expected, auth.token)
+		}
+	}
+
+	if _, err := newAuth("", filepath.Join(t.TempDir(), "missing"), "master-id-token"); err == nil {
+		t.Fatalf("want error for missing token file")
+	}
+}
+
+func TestRefreshTokenPath(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	dir := t.TempDir()
+	tokenPath := filepath.Join(dir, "token")
+	if err := ioutil.WriteFile(tokenPath, []byte("file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		Token     string
+		TokenPath string
+		EnvToken  string
+		Err       bool
+	}{
+		{"", tokenPath, "", false},
+		{"", tokenPath, "env-token", false},
+		{"explicit-token", tokenPath, "", true},
+		{"", "", "env-token", true},
+		{"", filepath.Join(dir, "missing"), "", true},
+		// default token file doesn't exist
+		{"", "", "", true},
+	}
+
+	for _, tc := range cases {
+		got, err := refreshTokenPath(tc.Token, tc.TokenPath, tc.EnvToken)
+		if tc.Err {
+			if err == nil {
+				t.Fatalf("want error for token %q, token_path %q and env token %q", tc.Token, tc.TokenPath, tc.EnvToken)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tokenPath {
+			t.Fatalf("want token path %q, got %q", tokenPath, got)
+		}
+	}
+
+	a := &tokenAuth{token: "file-token", path: tokenPath, log: zap.NewNop().Sugar()}
+	a.replace("regenerated-token", "token has been rejected")
+	got, err := readToken(tokenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "regenerated-token" {
+		t.Fatalf("want regenerated token written to token_path, got %q", got)
+	}
+}
+
+func TestNewHTTPTransport(t *testing.T) {
+	s := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer s.Close()
+
+	caCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: s.Certificate().Raw}))
+
+	cases := []struct {
+		CACertificate string
+		Insecure      bool
+		Success       bool
+	}{
+		{"", false, false},
+		{caCertificate, false, true},
+		{"", true, true},
+	}
+
+	for _, tc := range cases {
+		tr, err := newHTTPTransport(tc.CACertificate, tc.Insecure, "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := (&http.Client{Transport: tr}).Get(s.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		if (err == nil) != tc.Success {
+			t.Fatalf("want success %t with insecure %t and CA %t, got %v", tc.Success, tc.Insecure, tc.CACertificate != "", err)
+		}
+	}
+
+	if _, err := newHTTPTransport("-----BEGIN CERTIFICATE-----\n-----END CERTIFICATE-----", false, "", "", ""); err == nil {
+		t.Fatalf("want error for invalid certificate")
+	}
+}
+
+func TestNewHTTPTransportClientCertificate(t *testing.T) {
+	s := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	s.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	s.StartTLS()
+	defer s.Close()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clientCertificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+	clientKey := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}))
+
+	cases := []struct {
+		ClientCertificate string
+		ClientKey         string
+		Success           bool
+	}{
+		{"", "", false},
+		{clientCertificate, clientKey, true},
+	}
+
+	for _, tc := range cases {
+		tr, err := newHTTPTransport("", true, "", tc.ClientCertificate, tc.ClientKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := (&http.Client{Transport: tr}).Get(s.URL)
+		if err == nil {
+			resp.Body.Close()
+		}
+		if (err == nil) != tc.Success {
+			t.Fatalf("want success %t with client certificate %t, got %v", tc.Success, tc.ClientCertificate != "", err)
+		}
+	}
+
+	if _, err := newHTTPTransport("", false, "", clientCertificate, ""); err == nil {
+		t.Fatalf("want error for client certificate without key")
+	}
+}
+
+func TestNewHTTPTransportProxy(t *testing.T) {
+	tr, err := newHTTPTransport("", false, "http://proxy.example.com:3128", "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://kubermatic.example.com", nil)
+	u, err := tr.Proxy(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u == nil || u.Host != "proxy.example.com:3128" {
+		t.Fatalf("want proxy.example.com:3128 proxy, got %v", u)
+	}
+}
+
+func TestNewClientRequestTimeout(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer s.Close()
+
+	auth := &tokenAuth{token: "token"}
+	for _, tc := range []struct {
+		Timeout time.Duration
+		Fail    bool
+	}{
+		{50 * time.Millisecond, true},
+		{time.Minute, false},
+	} {
+		client, err := newClient(s.URL, auth, nil, tc.Timeout)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.Users.GetCurrentUser(users.NewGetCurrentUserParams(), auth)
+		if failed := err != nil; failed != tc.Fail {
+			t.Fatalf("want request with %s timeout failed=%t, got %v", tc.Timeout, tc.Fail, err)
+		}
+	}
+}
+
+func TestProviderSecretsSensitive(t *testing.T) {
+	var check func(path string, fields map[string]*schema.Schema)
+	check = func(path string, fields map[string]*schema.Schema) {
+		for name, s := range fields {
+			if isSecretField(name) && s.Type == schema.TypeString && !s.Sensitive {
+				t.Errorf("%s.%s holds a secret and must be sensitive", path, name)
+			}
+			if r, ok := s.Elem.(*schema.Resource); ok {
+				check(path+"."+name, r.Schema)
+			}
+		}
+	}
+
+	check("provider", testAccProvider.Schema)
+	for name, r := range testAccProvider.ResourcesMap {
+		check(name, r.Schema)
+	}
+	for name, r := range testAccProvider.DataSourcesMap {
+		check(name, r.Schema)
+	}
+}
+
+func isSecretField(name string) bool {
+	for _, suffix := range []string{"password", "secret", "token", "kubeconfig", "access_key", "access_key_id", "client_key"} {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package kubermatic
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	if l := newRateLimiter(0, 5); l != nil {
+		t.Fatalf("want no limiter without rate")
+	}
+
+	now := time.Unix(1600000000, 0)
+	l := newRateLimiter(2, 2)
+	cases := []struct {
+		After    time.Duration
+		Expected time.Duration
+	}{
+		// burst
+		{0, 0},
+		{0, 0},
+		// reserved in advance
+		{0, 500 * time.Millisecond},
+		{0, time.Second},
+		// refilled
+		{2 * time.Second, 0},
+		{10 * time.Second, 0},
+		{0, 0},
+		{0, 500 * time.Millisecond},
+	}
+
+	for i, tc := range cases {
+		now = now.Add(tc.After)
+		if got := l.reserve(now); got != tc.Expected {
+			t.Fatalf("want reserve %d to wait %s, got %s", i, tc.Expected, got)
+		}
+	}
+}
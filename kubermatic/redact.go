@@ -0,0 +1,71 @@
+package kubermatic
+
+import (
+	"encoding/json"
+)
+
+const redacted = "REDACTED"
+
+// cloudCredentialKeys are cloud spec fields holding credentials, they are
+// removed from the exported cluster spec.
+var cloudCredentialKeys = map[string]bool{
+	"accessKeyId":     true,
+	"accessKeySecret": true,
+	"apiKey":          true,
+	"clientSecret":    true,
+	"kubeconfig":      true,
+	"password":        true,
+	"secretAccessKey": true,
+	"serviceAccount":  true,
+	"token":           true,
+	"username":        true,
+}
+
+// secretKeys are request and response fields holding secrets in addition
+// to cloud credentials.
+var secretKeys = map[string]bool{
+	"access_token":  true,
+	"client_secret": true,
+	"id_token":      true,
+	"refresh_token": true,
+	"secret":        true,
+	"subject_token": true,
+}
+
+// isSecretKey reports whether API field holds a secret, such fields are
+// redacted from logs and error messages.
+func isSecretKey(key string) bool {
+	return cloudCredentialKeys[key] || secretKeys[key]
+}
+
+// redactJSON returns the JSON document with values of secret fields
+// replaced.
+func redactJSON(b []byte) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return "", err
+	}
+	redactSecrets(v)
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func redactSecrets(in interface{}) {
+	switch v := in.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if isSecretKey(key) {
+				v[key] = redacted
+				continue
+			}
+			redactSecrets(val)
+		}
+	case []interface{}:
+		for _, val := range v {
+			redactSecrets(val)
+		}
+	}
+}
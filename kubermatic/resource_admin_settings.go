@@ -0,0 +1,123 @@
+package kubermatic
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/admin"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+// adminSettingsID is the identifier of the global settings, there is only
+// one instance of them per Kubermatic installation.
+const adminSettingsID = "globalsettings"
+
+func resourceAdminSettings() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAdminSettingsCreate,
+		Read:   resourceAdminSettingsRead,
+		Update: resourceAdminSettingsUpdate,
+		Delete: resourceAdminSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"custom_links": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Custom links shown in the dashboard",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"label": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+							Description:  "Link label",
+						},
+						"url": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.IsURLWithHTTPorHTTPS,
+							Description:  "Link URL",
+						},
+						"icon": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "URL of the link icon",
+						},
+						"location": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "default",
+							Description: "Where the link is displayed in the dashboard, e.g. default or footer",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceAdminSettingsCreate(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	if err := patchAdminSettings(d, k); err != nil {
+		return err
+	}
+	d.SetId(adminSettingsID)
+	return resourceAdminSettingsRead(d, m)
+}
+
+func resourceAdminSettingsRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	r, err := k.client.Admin.GetKubermaticSettings(admin.NewGetKubermaticSettingsParams(), k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to get admin settings: %s", getErrorResponse(err))
+	}
+
+	if err := d.Set("custom_links", flattenCustomLinks(r.Payload.CustomLinks)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceAdminSettingsUpdate(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	if d.HasChange("custom_links") {
+		if err := patchAdminSettings(d, k); err != nil {
+			return err
+		}
+	}
+	return resourceAdminSettingsRead(d, m)
+}
+
+func resourceAdminSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	p := admin.NewPatchKubermaticSettingsParams()
+	p.SetPatch(newAdminSettingsPatch(nil))
+	if _, err := k.client.Admin.PatchKubermaticSettings(p, k.auth); err != nil {
+		return fmt.Errorf("unable to reset admin settings: %s", getErrorResponse(err))
+	}
+	return nil
+}
+
+func patchAdminSettings(d *schema.ResourceData, k *kubermaticProviderMeta) error {
+	p := admin.NewPatchKubermaticSettingsParams()
+	p.SetPatch(newAdminSettingsPatch(d.Get("custom_links").([]interface{})))
+	if _, err := k.client.Admin.PatchKubermaticSettings(p, k.auth); err != nil {
+		return fmt.Errorf("unable to patch admin settings: %s", getErrorResponse(err))
+	}
+	return nil
+}
+
+func newAdminSettingsPatch(customLinks []interface{}) interface{} {
+	links := expandCustomLinks(customLinks)
+	if links == nil {
+		// empty list has to be sent explicitly, null keeps current links
+		links = models.CustomLinks{}
+	}
+	return map[string]interface{}{
+		"customLinks": links,
+	}
+}
@@ -3,39 +3,68 @@ package kubermatic
 import (
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/addon"
+	"github.com/kubermatic/go-kubermatic/client/datacenter"
 	"github.com/kubermatic/go-kubermatic/client/project"
 	"github.com/kubermatic/go-kubermatic/models"
 )
 
 const (
 	healthStatusUp models.HealthStatus = 1
+
+	// maxClusterEvents bounds the number of events kept in state
+	maxClusterEvents = 10
+
+	// clusterNamespacePrefix prefixes cluster ID in control plane namespace name
+	clusterNamespacePrefix = "cluster-"
+
+	// clusterProgressInterval is the longest time between cluster creation
+	// progress logs
+	clusterProgressInterval = time.Minute
 )
 
+// clusterRetryRules match errors of clusters being updated concurrently or
+// still being set up by the seed controllers.
+var clusterRetryRules = []retryRule{
+	{code: http.StatusConflict},
+	{message: "cluster is still being provisioned"},
+}
+
 func resourceCluster() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceClusterCreate,
 		Read:   resourceClusterRead,
 		Update: resourceClusterUpdate,
 		Delete: resourceClusterDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Update: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "Reference project identifier",
+				Description: "Reference project identifier, provider default_project_id if not set",
 			},
 			"dc": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
+				Computed:    true,
 				ForceNew:    true,
-				Description: "Data center name",
+				Description: "Seed data center name, resolved from spec.cloud.dc if not set",
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -43,8 +72,9 @@ func resourceCluster() *schema.Resource {
 				Description: "Cluster name",
 			},
 			"labels": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateLabels,
 			},
 			"sshkeys": {
 				Type:     schema.TypeSet,
@@ -66,7 +96,7 @@ func resourceCluster() *schema.Resource {
 			"credential": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Cluster access credential",
+				Description: "Name of the preset providing cloud credentials, e.g. from the kubermatic_preset data source",
 			},
 			"type": {
 				Type:        schema.TypeString,
@@ -75,6 +105,104 @@ func resourceCluster() *schema.Resource {
 				Default:     "kubernetes",
 				Description: "Cluster type Kubernetes or OpenShift",
 			},
+			"node_deployment": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "Node deployment created together with the cluster, later changes are ignored, use kubermatic_node_deployment to manage node deployments independently",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.NoZeroValues,
+							Description:  "Node deployment name",
+						},
+						"spec": {
+							Type:        schema.TypeList,
+							Required:    true,
+							MaxItems:    1,
+							Description: "Node deployment specification",
+							Elem: &schema.Resource{
+								Schema: nodeDeploymentSpecFields(),
+							},
+						},
+					},
+				},
+			},
+			"node_deployment_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the node deployment created together with the cluster",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Adopt an existing cluster with the same name in the data center instead of creating a new one, only used on creation. Type and cloud of the existing cluster have to match, name, labels, spec and SSH keys are updated to the configured ones",
+			},
+			"events_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"warning", "normal"}, false),
+				Description:  "Type of cluster events to capture into events attribute, events are not captured if not set",
+			},
+			"events": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Most recent cluster events of events_type",
+				Elem: &schema.Resource{
+					Schema: clusterEventFields(),
+				},
+			},
+			"credentials_reference": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Reference to the secret holding cloud credentials, credentials themselves are never stored in state",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Secret name",
+						},
+						"namespace": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Secret namespace",
+						},
+					},
+				},
+			},
+			"cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Cluster identifier",
+			},
+			"seed_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Seed of the cluster data center",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address at which the cluster API server is available",
+			},
+			"namespace": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Namespace of the cluster control plane in the seed cluster",
+			},
+			"default_addons": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Addons installed by Kubermatic by default, they are reconciled by Kubermatic and can't be removed",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"creation_timestamp": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -106,14 +234,56 @@ func resourceCluster() *schema.Resource {
 				}
 				return false
 			}),
+			// node deployment is only sent on creation, it is managed by
+			// kubermatic_node_deployment or the user afterwards
+			func(d *schema.ResourceDiff, meta interface{}) error {
+				if d.Id() == "" {
+					return nil
+				}
+				return d.Clear("node_deployment")
+			},
 		),
 	}
 }
 
 func resourceClusterCreate(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
-	pID := d.Get("project_id").(string)
+	pID, err := getProjectID(d, k)
+	if err != nil {
+		return err
+	}
 	dc := d.Get("dc").(string)
+	if dc == "" {
+		seed, err := getDatacenterSeed(k, d.Get("spec.0.cloud.0.dc").(string))
+		if err != nil {
+			return err
+		}
+		dc = seed
+		d.Set("dc", dc)
+	}
+
+	if d.Get("adopt_existing").(bool) {
+		existing, err := findClusterByName(k, pID, dc, d.Get("name").(string))
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if err := checkAdoptedCluster(d, existing); err != nil {
+				return err
+			}
+			k.log.Infof("adopting existing cluster '%s'", existing.ID)
+			d.SetId(existing.ID)
+			// name, labels, spec and SSH keys of the existing cluster are
+			// set to the configured ones
+			return resourceClusterUpdate(d, m)
+		}
+	}
+
+	spec := expandClusterSpec(d.Get("spec").([]interface{}))
+	if err := validateCloudCredentials(spec.Cloud, d.Get("credential").(string)); err != nil {
+		return err
+	}
+
 	p := project.NewCreateClusterParams()
 
 	p.SetProjectID(pID)
@@ -121,11 +291,12 @@ func resourceClusterCreate(d *schema.ResourceData, m interface{}) error {
 	p.SetBody(&models.CreateClusterSpec{
 		Cluster: &models.Cluster{
 			Name:       d.Get("name").(string),
-			Spec:       expandClusterSpec(d.Get("spec").([]interface{})),
+			Spec:       spec,
 			Type:       d.Get("type").(string),
-			Labels:     getLabels(d),
+			Labels:     withDefaultLabels(k, getLabels(d)),
 			Credential: d.Get("credential").(string),
 		},
+		NodeDeployment: expandClusterNodeDeployment(d.Get("node_deployment").([]interface{})),
 	})
 
 	r, err := k.client.Project.CreateCluster(p, k.auth)
@@ -138,9 +309,155 @@ func resourceClusterCreate(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("cluster '%s' is not ready: %v", r.Payload.ID, err)
 	}
 
+	if name := d.Get("node_deployment.0.name").(string); name != "" {
+		nd, err := findNodeDeploymentByName(k, pID, dc, r.Payload.ID, name)
+		if err != nil {
+			return err
+		}
+		if nd != nil {
+			d.Set("node_deployment_id", nd.ID)
+		}
+	}
+
 	return resourceClusterRead(d, m)
 }
 
+// checkAdoptedCluster fails if attributes which force a new cluster differ
+// from the existing one, the adopted cluster would be replaced on the next
+// apply otherwise. Cloud provider settings are not compared, credentials
+// aren't returned by the API.
+func checkAdoptedCluster(d *schema.ResourceData, c *models.Cluster) error {
+	var diffs []string
+	if c.Type != d.Get("type").(string) {
+		diffs = append(diffs, "type")
+	}
+	cloud := expandClusterCloudSpec(d.Get("spec.0.cloud").([]interface{}))
+	var existing *models.CloudSpec
+	if c.Spec != nil {
+		existing = c.Spec.Cloud
+	}
+	switch {
+	case existing == nil || cloud == nil:
+		diffs = append(diffs, "spec.0.cloud")
+	case existing.DatacenterName != cloud.DatacenterName:
+		diffs = append(diffs, "spec.0.cloud.0.dc")
+	case cloudProviderName(existing) != cloudProviderName(cloud):
+		diffs = append(diffs, "spec.0.cloud")
+	}
+	if len(diffs) > 0 {
+		return fmt.Errorf("unable to adopt cluster '%s': existing cluster has different %s", c.ID, strings.Join(diffs, ", "))
+	}
+	return nil
+}
+
+func cloudProviderName(in *models.CloudSpec) string {
+	switch {
+	case in.Aws != nil:
+		return "aws"
+	case in.Azure != nil:
+		return "azure"
+	case in.Gcp != nil:
+		return "gcp"
+	case in.Openstack != nil:
+		return "openstack"
+	case in.Bringyourown != nil:
+		return "bringyourown"
+	}
+	return ""
+}
+
+func findNodeDeploymentByName(k *kubermaticProviderMeta, projectID, dc, clusterID, name string) (*models.NodeDeployment, error) {
+	p := project.NewListNodeDeploymentsParams()
+	p.SetProjectID(projectID)
+	p.SetDC(dc)
+	p.SetClusterID(clusterID)
+	r, err := k.client.Project.ListNodeDeployments(p, k.auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list node deployments of cluster '%s': %s", clusterID, getErrorResponse(err))
+	}
+	for _, nd := range r.Payload {
+		if nd != nil && nd.Name == name {
+			return nd, nil
+		}
+	}
+	return nil, nil
+}
+
+// validateCloudCredentials checks cloud credentials are configured unless
+// they are taken from the preset.
+func validateCloudCredentials(in *models.CloudSpec, preset string) error {
+	if in == nil || preset != "" {
+		return nil
+	}
+	if in.Aws != nil && (in.Aws.AccessKeyID == "" || in.Aws.SecretAccessKey == "") {
+		return fmt.Errorf("spec.0.cloud.0.aws: access_key_id and secret_access_key are required unless credential preset is set")
+	}
+	if a := in.Azure; a != nil && (a.TenantID == "" || a.SubscriptionID == "" || a.ClientID == "" || a.ClientSecret == "") {
+		return fmt.Errorf("spec.0.cloud.0.azure: tenant_id, subscription_id, client_id and client_secret are required unless credential preset is set")
+	}
+	if in.Gcp != nil && in.Gcp.ServiceAccount == "" {
+		return fmt.Errorf("spec.0.cloud.0.gcp: service_account is required unless credential preset is set")
+	}
+	return nil
+}
+
+// getDatacenterSeed returns seed of the data center, seeds are cached for
+// the provider run.
+func getDatacenterSeed(k *kubermaticProviderMeta, dc string) (string, error) {
+	k.seedsMu.Lock()
+	defer k.seedsMu.Unlock()
+
+	if seed, ok := k.seeds[dc]; ok {
+		return seed, nil
+	}
+
+	p := datacenter.NewGetDatacenterParams()
+	p.SetDC(dc)
+	r, err := k.client.Datacenter.GetDatacenter(p, k.auth)
+	if err != nil {
+		return "", fmt.Errorf("unable to get data center '%s': %s", dc, getErrorResponse(err))
+	}
+	if r.Payload.Spec == nil || r.Payload.Spec.Seed == "" {
+		return "", fmt.Errorf("unable to find seed of data center '%s'", dc)
+	}
+
+	if k.seeds == nil {
+		k.seeds = make(map[string]string)
+	}
+	k.seeds[dc] = r.Payload.Spec.Seed
+	return r.Payload.Spec.Seed, nil
+}
+
+func findClusterByName(k *kubermaticProviderMeta, projectID, dc, name string) (*models.Cluster, error) {
+	p := project.NewListClustersParams()
+	p.SetProjectID(projectID)
+	p.SetDC(dc)
+	r, err := k.client.Project.ListClusters(p, k.auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list clusters of project '%s': %s", projectID, getErrorResponse(err))
+	}
+
+	var found []*models.Cluster
+	for _, c := range r.Payload {
+		if c.Name == name {
+			found = append(found, c)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	}
+
+	ids := make([]string, len(found))
+	for i, c := range found {
+		ids[i] = c.ID
+	}
+	return nil, fmt.Errorf("multiple clusters named '%s' found in data center '%s': %s", name, dc, strings.Join(ids, ", "))
+}
+
 func getLabels(d *schema.ResourceData) map[string]string {
 	var labels map[string]string
 	if v := d.Get("labels"); v != nil {
@@ -180,10 +497,12 @@ func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
 	if err != nil {
 		return err
 	}
+	labels = excludeDefaultLabels(k, labels, d.Get("labels").(map[string]interface{}))
 	if err := d.Set("labels", labels); err != nil {
 		return err
 	}
 
+	d.Set("cluster_id", r.Payload.ID)
 	d.Set("name", r.Payload.Name)
 
 	// TODO: check why API returns an empty credential field even if it is set
@@ -200,6 +519,28 @@ func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	if r.Payload.Spec != nil {
+		if err := d.Set("credentials_reference", flattenCredentialsReference(r.Payload.Spec.Cloud)); err != nil {
+			return err
+		}
+	}
+
+	if r.Payload.Status != nil {
+		d.Set("url", r.Payload.Status.URL)
+	}
+
+	// the API doesn't return the namespace, Kubermatic always names it
+	// after the cluster
+	d.Set("namespace", clusterNamespacePrefix+r.Payload.ID)
+
+	if dc := d.Get("spec.0.cloud.0.dc").(string); dc != "" {
+		seed, err := getDatacenterSeed(k, dc)
+		if err != nil {
+			return err
+		}
+		d.Set("seed_name", seed)
+	}
+
 	d.Set("creation_timestamp", r.Payload.CreationTimestamp.String())
 
 	d.Set("deletion_timestamp", r.Payload.DeletionTimestamp.String())
@@ -212,9 +553,68 @@ func resourceClusterRead(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	events, err := getClusterEvents(d, k)
+	if err != nil {
+		return err
+	}
+	if err := d.Set("events", events); err != nil {
+		return err
+	}
+
+	// addons are informational, listing them must not fail refreshes
+	addons, err := getClusterDefaultAddons(d, k)
+	if err != nil {
+		k.log.Warnf("keeping last known default addons: %v", err)
+	} else if err := d.Set("default_addons", addons); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// getClusterDefaultAddons returns sorted names of default addons installed
+// in the cluster.
+func getClusterDefaultAddons(d *schema.ResourceData, k *kubermaticProviderMeta) ([]string, error) {
+	p := addon.NewListAddonsParams()
+	p.SetProjectID(d.Get("project_id").(string))
+	p.SetDC(d.Get("dc").(string))
+	p.SetClusterID(d.Id())
+	r, err := k.client.Addon.ListAddons(p, k.auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list cluster '%s' addons: %s", d.Id(), getErrorResponse(err))
+	}
+
+	names := []string{}
+	for _, a := range r.Payload {
+		if a.Spec != nil && a.Spec.IsDefault {
+			names = append(names, a.Name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// getClusterEvents returns flattened most recent events of configured type.
+func getClusterEvents(d *schema.ResourceData, k *kubermaticProviderMeta) ([]interface{}, error) {
+	eventsType := d.Get("events_type").(string)
+	if eventsType == "" {
+		return []interface{}{}, nil
+	}
+
+	p := project.NewGetClusterEventsParams()
+	p.SetProjectID(d.Get("project_id").(string))
+	p.SetDC(d.Get("dc").(string))
+	p.SetClusterID(d.Id())
+	p.SetType(&eventsType)
+
+	r, err := k.client.Project.GetClusterEvents(p, k.auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get cluster '%s' events: %s", d.Id(), getErrorResponse(err))
+	}
+
+	return flattenClusterEvents(r.Payload, maxClusterEvents), nil
+}
+
 // excludeProjectLabels excludes labels defined in project.
 // Project labels propogated to clusters. For better predictability of
 // cluster's labels changes, project's labels are excluded from cluster state.
@@ -257,17 +657,37 @@ func getClusterAssignedSSHKeys(d *schema.ResourceData, k *kubermaticProviderMeta
 // terraform state it creating state diff that might force undesired updates and
 // even force replacement of a cluster. Solution is to set values for preserved
 // values in flattened object before comitting it to state.
+// Credentials are never read back, so values coming from other providers,
+// e.g. Vault, are only compared with what was configured.
 type clusterPreserveValues struct {
-	openstackUsername interface{}
-	openstackPassword interface{}
-	openstackTenant   interface{}
+	openstackUsername  interface{}
+	openstackPassword  interface{}
+	openstackTenant    interface{}
+	awsAccessKeyID     interface{}
+	awsSecretAccessKey interface{}
+
+	azureTenantID       interface{}
+	azureSubscriptionID interface{}
+	azureClientID       interface{}
+	azureClientSecret   interface{}
+
+	gcpServiceAccount interface{}
 }
 
 func readClusterPreserveValues(d *schema.ResourceData) clusterPreserveValues {
 	return clusterPreserveValues{
-		openstackUsername: d.Get("spec.0.cloud.0.openstack.0.username"),
-		openstackPassword: d.Get("spec.0.cloud.0.openstack.0.password"),
-		openstackTenant:   d.Get("spec.0.cloud.0.openstack.0.tenant"),
+		openstackUsername:  d.Get("spec.0.cloud.0.openstack.0.username"),
+		openstackPassword:  d.Get("spec.0.cloud.0.openstack.0.password"),
+		openstackTenant:    d.Get("spec.0.cloud.0.openstack.0.tenant"),
+		awsAccessKeyID:     d.Get("spec.0.cloud.0.aws.0.access_key_id"),
+		awsSecretAccessKey: d.Get("spec.0.cloud.0.aws.0.secret_access_key"),
+
+		azureTenantID:       d.Get("spec.0.cloud.0.azure.0.tenant_id"),
+		azureSubscriptionID: d.Get("spec.0.cloud.0.azure.0.subscription_id"),
+		azureClientID:       d.Get("spec.0.cloud.0.azure.0.client_id"),
+		azureClientSecret:   d.Get("spec.0.cloud.0.azure.0.client_secret"),
+
+		gcpServiceAccount: d.Get("spec.0.cloud.0.gcp.0.service_account"),
 	}
 }
 
@@ -278,10 +698,14 @@ func resourceClusterUpdate(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
 
 	if d.HasChanges("name", "labels", "spec") {
-		patchClusterFields(d, k)
+		if err := patchClusterFields(d, k); err != nil {
+			return err
+		}
 	}
 	if d.HasChange("sshkeys") {
-		updateClusterSSHKeys(d, k)
+		if err := updateClusterSSHKeys(d, k); err != nil {
+			return fmt.Errorf("unable to update SSH keys of cluster '%s': %s", d.Id(), getErrorResponse(err))
+		}
 	}
 
 	if err := waitClusterReady(k, d); err != nil {
@@ -299,16 +723,14 @@ func patchClusterFields(d *schema.ResourceData, k *kubermaticProviderMeta) error
 	name := d.Get("name").(string)
 	version := d.Get("spec.0.version").(string)
 	auditLogging := d.Get("spec.0.audit_logging").(bool)
-	labels := d.Get("labels")
-	p.SetPatch(newClusterPatch(name, version, auditLogging, labels))
+	podNodeSelector := d.Get("spec.0.pod_node_selector").(bool)
+	labels := withDefaultLabels(k, getLabels(d))
+	p.SetPatch(newClusterPatch(name, version, auditLogging, podNodeSelector, labels, newClusterCredentialsPatch(d)))
 
-	err := resource.Retry(d.Timeout(schema.TimeoutUpdate), func() *resource.RetryError {
+	err := retry(k, getTimeout(d, k, schema.TimeoutUpdate), func() *resource.RetryError {
 		_, err := k.client.Project.PatchCluster(p, k.auth)
 		if err != nil {
-			if e, ok := err.(*project.PatchClusterDefault); ok && e.Code() == http.StatusConflict {
-				return resource.RetryableError(fmt.Errorf("cluster patch conflict: %w", err))
-			}
-			return resource.NonRetryableError(fmt.Errorf("patch cluster '%s': %v", d.Id(), err))
+			return classifyError(err, clusterRetryRules, fmt.Errorf("unable to patch cluster '%s': %s", d.Id(), getErrorResponse(err)))
 		}
 		return nil
 	})
@@ -371,7 +793,10 @@ func updateClusterSSHKeys(d *schema.ResourceData, k *kubermaticProviderMeta) err
 }
 
 func waitClusterReady(k *kubermaticProviderMeta, d *schema.ResourceData) error {
-	return resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	start := time.Now()
+	var lastPending string
+	var lastLogged time.Time
+	return retry(k, getTimeout(d, k, schema.TimeoutCreate), func() *resource.RetryError {
 		hp := project.NewGetClusterHealthParams()
 		hp.SetClusterID(d.Id())
 		hp.SetProjectID(d.Get("project_id").(string))
@@ -379,36 +804,104 @@ func waitClusterReady(k *kubermaticProviderMeta, d *schema.ResourceData) error {
 
 		r, err := k.client.Project.GetClusterHealth(hp, k.auth)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("unable to get cluster '%s' health: %s", d.Id(), getErrorResponse(err)))
+			return classifyError(err, clusterRetryRules, fmt.Errorf("unable to get cluster '%s' health: %s", d.Id(), getErrorResponse(err)))
 		}
 
-		if r.Payload.Apiserver == healthStatusUp &&
-			r.Payload.CloudProviderInfrastructure == healthStatusUp &&
-			r.Payload.Controller == healthStatusUp &&
-			r.Payload.Etcd == healthStatusUp &&
-			r.Payload.MachineController == healthStatusUp &&
-			r.Payload.Scheduler == healthStatusUp &&
-			r.Payload.UserClusterControllerManager == healthStatusUp {
+		pending := strings.Join(pendingHealthComponents(r.Payload), ", ")
+		if pending == "" {
+			k.log.Infof("cluster '%s' is ready after %s", d.Id(), time.Since(start).Round(time.Second))
 			return nil
 		}
 
-		k.log.Debugf("waiting for cluster '%s' to be ready, %+v", d.Id(), r.Payload)
-		return resource.RetryableError(fmt.Errorf("waiting for cluster '%s' to be ready", d.Id()))
+		// log on progress, and periodically to show the provider is not stuck
+		if pending != lastPending || time.Since(lastLogged) >= clusterProgressInterval {
+			k.log.Infof("cluster '%s' is not ready after %s, waiting for %s", d.Id(), time.Since(start).Round(time.Second), pending)
+			lastPending, lastLogged = pending, time.Now()
+		}
+		return resource.RetryableError(fmt.Errorf("waiting for cluster '%s' to be ready, pending: %s", d.Id(), pending))
 	})
 }
 
-func newClusterPatch(name, version string, auditLogging bool, labels interface{}) interface{} {
+// pendingHealthComponents returns names of cluster components which are
+// not up, in the order they usually become ready.
+func pendingHealthComponents(h *models.ClusterHealth) []string {
+	var pending []string
+	for _, c := range []struct {
+		name   string
+		status models.HealthStatus
+	}{
+		{"etcd", h.Etcd},
+		{"apiserver", h.Apiserver},
+		{"controller", h.Controller},
+		{"scheduler", h.Scheduler},
+		{"machine controller", h.MachineController},
+		{"user cluster controller manager", h.UserClusterControllerManager},
+		{"cloud provider infrastructure", h.CloudProviderInfrastructure},
+	} {
+		if c.status != healthStatusUp {
+			pending = append(pending, c.name)
+		}
+	}
+	return pending
+}
+
+func newClusterPatch(name, version string, auditLogging, podNodeSelector bool, labels, cloud interface{}) interface{} {
 	// TODO(furkhat): change to dedicated struct when API has it.
+	spec := map[string]interface{}{
+		"auditLogging": map[string]bool{
+			"enabled": auditLogging,
+		},
+		"usePodNodeSelectorAdmissionPlugin": podNodeSelector,
+		"version":                           version,
+	}
+	if cloud != nil {
+		spec["cloud"] = cloud
+	}
 	return map[string]interface{}{
 		"name":   name,
 		"labels": labels,
-		"spec": map[string]interface{}{
-			"auditLogging": map[string]bool{
-				"enabled": auditLogging,
+		"spec":   spec,
+	}
+}
+
+// newClusterCredentialsPatch returns cloud patch with changed credentials,
+// nil if credentials are unchanged.
+func newClusterCredentialsPatch(d *schema.ResourceData) interface{} {
+	if d.HasChanges("spec.0.cloud.0.openstack.0.username", "spec.0.cloud.0.openstack.0.password") {
+		return map[string]interface{}{
+			"openstack": map[string]interface{}{
+				"username": d.Get("spec.0.cloud.0.openstack.0.username"),
+				"password": d.Get("spec.0.cloud.0.openstack.0.password"),
 			},
-			"version": version,
-		},
+		}
+	}
+	if d.HasChanges("spec.0.cloud.0.aws.0.access_key_id", "spec.0.cloud.0.aws.0.secret_access_key") {
+		return map[string]interface{}{
+			"aws": map[string]interface{}{
+				"accessKeyId":     d.Get("spec.0.cloud.0.aws.0.access_key_id"),
+				"secretAccessKey": d.Get("spec.0.cloud.0.aws.0.secret_access_key"),
+			},
+		}
+	}
+	if d.HasChanges("spec.0.cloud.0.azure.0.tenant_id", "spec.0.cloud.0.azure.0.subscription_id",
+		"spec.0.cloud.0.azure.0.client_id", "spec.0.cloud.0.azure.0.client_secret") {
+		return map[string]interface{}{
+			"azure": map[string]interface{}{
+				"tenantID":       d.Get("spec.0.cloud.0.azure.0.tenant_id"),
+				"subscriptionID": d.Get("spec.0.cloud.0.azure.0.subscription_id"),
+				"clientID":       d.Get("spec.0.cloud.0.azure.0.client_id"),
+				"clientSecret":   d.Get("spec.0.cloud.0.azure.0.client_secret"),
+			},
+		}
+	}
+	if d.HasChange("spec.0.cloud.0.gcp.0.service_account") {
+		return map[string]interface{}{
+			"gcp": map[string]interface{}{
+				"serviceAccount": encodeGCPServiceAccount(d.Get("spec.0.cloud.0.gcp.0.service_account").(string)),
+			},
+		}
 	}
+	return nil
 }
 
 func resourceClusterDelete(d *schema.ResourceData, m interface{}) error {
@@ -423,7 +916,7 @@ func resourceClusterDelete(d *schema.ResourceData, m interface{}) error {
 	p.SetClusterID(cID)
 
 	deleteSent := false
-	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	return retry(k, getTimeout(d, k, schema.TimeoutDelete), func() *resource.RetryError {
 		if !deleteSent {
 			_, err := k.client.Project.DeleteCluster(p, k.auth)
 			if err != nil {
@@ -438,7 +931,7 @@ func resourceClusterDelete(d *schema.ResourceData, m interface{}) error {
 				if _, ok := err.(*project.DeleteClusterForbidden); ok {
 					return resource.RetryableError(err)
 				}
-				return resource.NonRetryableError(fmt.Errorf("unable to delete cluster '%s': %s", cID, getErrorResponse(err)))
+				return classifyError(err, clusterRetryRules, fmt.Errorf("unable to delete cluster '%s': %s", cID, getErrorResponse(err)))
 			}
 			deleteSent = true
 		}
@@ -458,7 +951,7 @@ func resourceClusterDelete(d *schema.ResourceData, m interface{}) error {
 			if _, ok := err.(*project.GetClusterForbidden); ok {
 				return resource.RetryableError(err)
 			}
-			return resource.NonRetryableError(fmt.Errorf("unable to get cluster '%s': %s", cID, getErrorResponse(err)))
+			return classifyError(err, clusterRetryRules, fmt.Errorf("unable to get cluster '%s': %s", cID, getErrorResponse(err)))
 		}
 
 		k.log.Debugf("cluster '%s' deletion in progress, deletionTimestamp: %s",
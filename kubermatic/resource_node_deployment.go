@@ -10,12 +10,24 @@ import (
 	"github.com/kubermatic/go-kubermatic/models"
 )
 
+// nodeDeploymentRetryRules match errors returned while the cluster API
+// server is not reachable yet, e.g. right after the cluster is created.
+var nodeDeploymentRetryRules = []retryRule{
+	{code: http.StatusConflict},
+	{message: "cluster is still being provisioned"},
+	{message: "connection refused"},
+}
+
 func resourceNodeDeployment() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceNodeDeploymentCreate,
 		Read:   resourceNodeDeploymentRead,
 		Update: resourceNodeDeploymentUpdate,
 		Delete: resourceNodeDeploymentDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"dc": {
@@ -25,8 +37,9 @@ func resourceNodeDeployment() *schema.Resource {
 			},
 			"project_id": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Reference project identifier",
+				Optional:    true,
+				Computed:    true,
+				Description: "Reference project identifier, provider default_project_id if not set",
 			},
 			"cluster_id": {
 				Type:        schema.TypeString,
@@ -48,6 +61,31 @@ func resourceNodeDeployment() *schema.Resource {
 					Schema: nodeDeploymentSpecFields(),
 				},
 			},
+			"ready_replicas": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of ready nodes",
+			},
+			"available_replicas": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of nodes ready for at least the minimum ready time",
+			},
+			"unavailable_replicas": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of nodes still required for the node deployment to be fully available",
+			},
+			"updated_replicas": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of nodes having the current node specification",
+			},
+			"node_deployment_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Node deployment identifier",
+			},
 			"creation_timestamp": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -65,16 +103,23 @@ func resourceNodeDeployment() *schema.Resource {
 func resourceNodeDeploymentCreate(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
 	dc := d.Get("dc").(string)
-	pID := d.Get("project_id").(string)
+	pID, err := getProjectID(d, k)
+	if err != nil {
+		return err
+	}
 	cID := d.Get("cluster_id").(string)
 	p := project.NewCreateNodeDeploymentParams()
 
 	p.SetProjectID(pID)
 	p.SetClusterID(cID)
 	p.SetDC(dc)
+	spec := expandNodeDeploymentSpec(d.Get("spec").([]interface{}))
+	if spec != nil && spec.Template != nil {
+		spec.Template.Labels = withDefaultLabels(k, spec.Template.Labels)
+	}
 	p.SetBody(&models.NodeDeployment{
 		Name: d.Get("name").(string),
-		Spec: expandNodeDeploymentSpec(d.Get("spec").([]interface{})),
+		Spec: spec,
 	})
 
 	r, err := k.client.Project.CreateNodeDeployment(p, k.auth)
@@ -83,7 +128,7 @@ func resourceNodeDeploymentCreate(d *schema.ResourceData, m interface{}) error {
 	}
 	nID := r.Payload.ID
 
-	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+	err = retry(k, getTimeout(d, k, schema.TimeoutCreate), func() *resource.RetryError {
 		p := project.NewGetNodeDeploymentParams()
 		p.SetProjectID(pID)
 		p.SetClusterID(cID)
@@ -92,7 +137,7 @@ func resourceNodeDeploymentCreate(d *schema.ResourceData, m interface{}) error {
 
 		r, err := k.client.Project.GetNodeDeployment(p, k.auth)
 		if err != nil {
-			return resource.NonRetryableError(fmt.Errorf("unable to get node deployment '%s' status: %s", nID, getErrorResponse(err)))
+			return classifyError(err, nodeDeploymentRetryRules, fmt.Errorf("unable to get node deployment '%s' status: %s", nID, getErrorResponse(err)))
 		}
 
 		if r.Payload.Status.ReadyReplicas < *r.Payload.Spec.Replicas {
@@ -133,16 +178,30 @@ func resourceNodeDeploymentRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("unable to get node deployment '%s': %s", d.Id(), getErrorResponse(err))
 	}
 
+	err = d.Set("node_deployment_id", r.Payload.ID)
+	if err != nil {
+		return err
+	}
+
 	err = d.Set("name", r.Payload.Name)
 	if err != nil {
 		return err
 	}
 
+	if r.Payload.Spec != nil && r.Payload.Spec.Template != nil {
+		configured, _ := d.Get("spec.0.template.0.labels").(map[string]interface{})
+		r.Payload.Spec.Template.Labels = excludeDefaultLabels(k, r.Payload.Spec.Template.Labels, configured)
+	}
+
 	err = d.Set("spec", flattenNodeDeploymentSpec(r.Payload.Spec))
 	if err != nil {
 		return err
 	}
 
+	for key, v := range flattenNodeDeploymentStatus(r.Payload.Status) {
+		d.Set(key, v)
+	}
+
 	err = d.Set("creation_timestamp", r.Payload.CreationTimestamp.String())
 	if err != nil {
 		return err
@@ -181,7 +240,7 @@ func resourceNodeDeploymentDelete(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("unable to delete node deployment '%s': %s", nID, getErrorResponse(err))
 	}
 
-	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	return retry(k, getTimeout(d, k, schema.TimeoutDelete), func() *resource.RetryError {
 		p := project.NewGetNodeDeploymentParams()
 		p.SetDC(dc)
 		p.SetProjectID(pID)
@@ -195,7 +254,7 @@ func resourceNodeDeploymentDelete(d *schema.ResourceData, m interface{}) error {
 				d.SetId("")
 				return nil
 			}
-			return resource.NonRetryableError(fmt.Errorf("unable to get node deployment '%s': %s", nID, getErrorResponse(err)))
+			return classifyError(err, nodeDeploymentRetryRules, fmt.Errorf("unable to get node deployment '%s': %s", nID, getErrorResponse(err)))
 		}
 
 		k.log.Debugf("node deployment '%s' deletion in progress, deletionTimestamp: %s",
@@ -3,10 +3,13 @@ package kubermatic
 import (
 	"fmt"
 	"net/http"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/kubermatic/go-kubermatic/client/project"
+	"github.com/kubermatic/go-kubermatic/client/users"
 	"github.com/kubermatic/go-kubermatic/models"
 )
 
@@ -22,7 +25,11 @@ func resourceProject() *schema.Resource {
 		Update: resourceProjectUpdate,
 		Delete: resourceProjectDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceProjectImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+			Delete: schema.DefaultTimeout(defaultResourceTimeout),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,16 +39,59 @@ func resourceProject() *schema.Resource {
 				Description: "Project name",
 			},
 			"labels": {
-				Type:        schema.TypeMap,
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Description:  "Project labels",
+				Elem:         schema.TypeString,
+				ValidateFunc: validateLabels,
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Project labels",
-				Elem:        schema.TypeString,
+				Default:     false,
+				Description: "Adopt an existing project with the same name instead of creating a new one, only used on creation. Owner and labels of the existing project are updated to the configured ones",
+			},
+			"owner_email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Email of a user made owner of the project, the previously configured owner becomes an editor when it is changed",
+			},
+			"owners": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Owners of the project",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the owner",
+						},
+						"email": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Email of the owner",
+						},
+					},
+				},
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Refuse to destroy the project, it has to be disabled and applied before the project can be destroyed",
 			},
 			"status": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "Status represents the current state of the project",
 			},
+			"project_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Project identifier",
+			},
 			"creation_timestamp": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -58,6 +108,21 @@ func resourceProject() *schema.Resource {
 
 func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
+
+	if d.Get("adopt_existing").(bool) {
+		existing, err := findProjectByName(k, d.Get("name").(string))
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			k.log.Infof("adopting existing project '%s'", existing.ID)
+			d.SetId(existing.ID)
+			// owner and labels of the existing project are set to the
+			// configured ones
+			return resourceProjectUpdate(d, m)
+		}
+	}
+
 	p := project.NewCreateProjectParams()
 
 	p.Body.Name = d.Get("name").(string)
@@ -68,6 +133,7 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 			p.Body.Labels[key] = val.(string)
 		}
 	}
+	p.Body.Labels = withDefaultLabels(k, p.Body.Labels)
 
 	r, err := k.client.Project.CreateProject(p, k.auth)
 	if err != nil {
@@ -76,37 +142,87 @@ func resourceProjectCreate(d *schema.ResourceData, m interface{}) error {
 	d.SetId(r.Payload.ID)
 
 	id := r.Payload.ID
-	createStateConf := &resource.StateChangeConf{
-		Pending: []string{
-			projectInactive,
-		},
-		Target: []string{
-			projectActive,
-		},
-		Refresh: func() (interface{}, string, error) {
-			p := project.NewGetProjectParams()
-			r, err := k.client.Project.GetProject(p.WithProjectID(id), k.auth)
-			if err != nil {
-				if e, ok := err.(*project.GetProjectDefault); ok && (e.Code() == http.StatusForbidden || e.Code() == http.StatusNotFound) {
-					return r, projectInactive, nil
-				}
-				return nil, "", err
+	err = retry(k, getTimeout(d, k, schema.TimeoutCreate), func() *resource.RetryError {
+		p := project.NewGetProjectParams()
+		r, err := k.client.Project.GetProject(p.WithProjectID(id), k.auth)
+		if err != nil {
+			if e, ok := err.(*project.GetProjectDefault); ok && (e.Code() == http.StatusForbidden || e.Code() == http.StatusNotFound) {
+				return resource.RetryableError(fmt.Errorf("project '%s' is not available yet", id))
 			}
-			k.log.Debugf("creating project '%s', currently in '%s' state", id, r.Payload.Status)
-			return r, r.Payload.Status, nil
-		},
-		Timeout:    d.Timeout(schema.TimeoutCreate),
-		MinTimeout: retryTimeout,
-		Delay:      requestDelay,
-	}
-
-	if _, err := createStateConf.WaitForState(); err != nil {
+			return resource.NonRetryableError(err)
+		}
+		k.log.Debugf("creating project '%s', currently in '%s' state", id, r.Payload.Status)
+		switch r.Payload.Status {
+		case projectActive:
+			return nil
+		case projectInactive:
+			return resource.RetryableError(fmt.Errorf("project '%s' is in '%s' state", id, r.Payload.Status))
+		}
+		return resource.NonRetryableError(fmt.Errorf("unexpected project '%s' state '%s'", id, r.Payload.Status))
+	})
+	if err != nil {
 		k.log.Debugf("error while waiting for project '%s' to be created: %s", id, err)
 		return fmt.Errorf("error while waiting for project '%s' to be created: %s", id, err)
 	}
+	if err := transferProjectOwnership(k, id, d.Get("owner_email").(string), ""); err != nil {
+		return err
+	}
 	return resourceProjectRead(d, m)
 }
 
+// resourceProjectImport imports a project by identifier or by name.
+func resourceProjectImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	k := m.(*kubermaticProviderMeta)
+
+	p := project.NewGetProjectParams()
+	_, err := k.client.Project.GetProject(p.WithProjectID(d.Id()), k.auth)
+	if err == nil {
+		return []*schema.ResourceData{d}, nil
+	}
+	if e, ok := err.(*project.GetProjectDefault); !ok || (e.Code() != http.StatusForbidden && e.Code() != http.StatusNotFound) {
+		return nil, fmt.Errorf("unable to get project '%s': %s", d.Id(), getErrorResponse(err))
+	}
+
+	found, err := findProjectByName(k, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no project with identifier or name '%s' found", d.Id())
+	}
+	d.SetId(found.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// findProjectByName returns the project with the name, nil if there is
+// none. Project names are not unique, so it fails if more projects match.
+func findProjectByName(k *kubermaticProviderMeta, name string) (*models.Project, error) {
+	r, err := k.client.Project.ListProjects(project.NewListProjectsParams(), k.auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list projects: %s", getErrorResponse(err))
+	}
+
+	var found []*models.Project
+	for _, p := range r.Payload {
+		if p.Name == name {
+			found = append(found, p)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	}
+
+	ids := make([]string, len(found))
+	for i, p := range found {
+		ids[i] = p.ID
+	}
+	return nil, fmt.Errorf("multiple projects named '%s' found: %s", name, strings.Join(ids, ", "))
+}
+
 func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
 	p := project.NewGetProjectParams()
@@ -124,10 +240,19 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 		return fmt.Errorf("unable to get project '%s': %s", d.Id(), getErrorResponse(err))
 	}
 
-	if err := d.Set("labels", r.Payload.Labels); err != nil {
+	labels := excludeDefaultLabels(k, r.Payload.Labels, d.Get("labels").(map[string]interface{}))
+	if err := d.Set("labels", labels); err != nil {
 		return err
 	}
+	d.Set("project_id", r.Payload.ID)
 	d.Set("name", r.Payload.Name)
+	if err := d.Set("owners", flattenProjectOwners(r.Payload.Owners)); err != nil {
+		return err
+	}
+	if owner := d.Get("owner_email").(string); owner != "" && !isProjectOwner(r.Payload.Owners, owner) {
+		// owner_email is applied again
+		d.Set("owner_email", "")
+	}
 	d.Set("status", r.Payload.Status)
 	d.Set("creation_timestamp", r.Payload.CreationTimestamp.String())
 	d.Set("deletion_timestamp", r.Payload.DeletionTimestamp.String())
@@ -136,6 +261,17 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 
 func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
+	if d.HasChange("owner_email") {
+		previous, owner := d.GetChange("owner_email")
+		if err := transferProjectOwnership(k, d.Id(), owner.(string), previous.(string)); err != nil {
+			return err
+		}
+	}
+	if !d.HasChanges("name", "labels") {
+		// deletion_protection and owner_email are not stored in the project
+		return resourceProjectRead(d, m)
+	}
+
 	p := project.NewUpdateProjectParams()
 	p.Body = &models.Project{
 		// name is always required for update requests, otherwise bad request returns
@@ -151,6 +287,7 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 		for key, val := range d.Get("labels").(map[string]interface{}) {
 			p.Body.Labels[key] = val.(string)
 		}
+		p.Body.Labels = withDefaultLabels(k, p.Body.Labels)
 	}
 
 	_, err := k.client.Project.UpdateProject(p.WithProjectID(d.Id()), k.auth)
@@ -161,15 +298,84 @@ func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	return resourceProjectRead(d, m)
 }
 
+// transferProjectOwnership makes the user with the owner email an owner of
+// the project, adding it to the project if needed. The previous owner
+// stays in the project as an editor.
+func transferProjectOwnership(k *kubermaticProviderMeta, projectID, owner, previous string) error {
+	if owner == "" {
+		return nil
+	}
+
+	p := users.NewGetUsersForProjectParams()
+	p.SetProjectID(projectID)
+	r, err := k.client.Users.GetUsersForProject(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to list users of project '%s': %s", projectID, getErrorResponse(err))
+	}
+
+	var ownerUser, previousUser *models.User
+	for _, u := range r.Payload {
+		switch {
+		case strings.EqualFold(u.Email, owner):
+			ownerUser = u
+		case previous != "" && strings.EqualFold(u.Email, previous):
+			previousUser = u
+		}
+	}
+
+	if ownerUser == nil {
+		p := users.NewAddUserToProjectParams()
+		p.SetProjectID(projectID)
+		p.SetBody(newProjectUser("", owner, projectID, "owners"))
+		if _, err := k.client.Users.AddUserToProject(p, k.auth); err != nil {
+			return fmt.Errorf("unable to add owner '%s' to project '%s': %s", owner, projectID, getErrorResponse(err))
+		}
+	} else if projectUserGroup(ownerUser, projectID) != "owners" {
+		if err := editProjectUserGroup(k, projectID, ownerUser, "owners"); err != nil {
+			return err
+		}
+	}
+
+	if previousUser != nil && projectUserGroup(previousUser, projectID) == "owners" {
+		return editProjectUserGroup(k, projectID, previousUser, "editors")
+	}
+	return nil
+}
+
+func editProjectUserGroup(k *kubermaticProviderMeta, projectID string, user *models.User, group string) error {
+	p := users.NewEditUserInProjectParams()
+	p.SetProjectID(projectID)
+	p.SetUserID(user.ID)
+	p.SetBody(newProjectUser(user.ID, user.Email, projectID, group))
+	if _, err := k.client.Users.EditUserInProject(p, k.auth); err != nil {
+		return fmt.Errorf("unable to move user '%s' to %s of project '%s': %s", user.Email, group, projectID, getErrorResponse(err))
+	}
+	return nil
+}
+
+// isProjectOwner reports whether the email belongs to one of the owners.
+func isProjectOwner(owners []*models.User, email string) bool {
+	for _, o := range owners {
+		if o != nil && strings.EqualFold(o.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
 func resourceProjectDelete(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("unable to delete project '%s': deletion_protection is enabled, set it to false and apply before destroying the project", d.Id())
+	}
+
 	p := project.NewDeleteProjectParams()
 	_, err := k.client.Project.DeleteProject(p.WithProjectID(d.Id()), k.auth)
 	if err != nil {
 		return fmt.Errorf("unable to delete project '%s': %s", d.Id(), getErrorResponse(err))
 	}
 
-	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+	return retry(k, getTimeout(d, k, schema.TimeoutDelete), func() *resource.RetryError {
 		p := project.NewGetProjectParams()
 		r, err := k.client.Project.GetProject(p.WithProjectID(d.Id()), k.auth)
 		if err != nil {
@@ -2,14 +2,18 @@ package kubermatic
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/kubermatic/go-kubermatic/client/project"
 	"github.com/kubermatic/go-kubermatic/models"
+	"go.uber.org/zap"
 )
 
 func init() {
@@ -89,6 +93,43 @@ func TestAccKubermaticProject_Basic(t *testing.T) {
 	})
 }
 
+func TestProjectDeletionProtection(t *testing.T) {
+	r := resourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                "foobar",
+		"deletion_protection": true,
+	})
+	d.SetId("abcdef")
+
+	// client is not set, the API must not be called
+	err := r.Delete(d, &kubermaticProviderMeta{})
+	if err == nil || !strings.Contains(err.Error(), "deletion_protection") {
+		t.Fatalf("want deletion_protection error, got %v", err)
+	}
+}
+
+func TestIsProjectOwner(t *testing.T) {
+	owners := []*models.User{
+		{Email: "jane@example.com"},
+		nil,
+		{Email: "John@example.com"},
+	}
+	cases := []struct {
+		Email string
+		Owner bool
+	}{
+		{"jane@example.com", true},
+		{"john@example.com", true},
+		{"joe@example.com", false},
+	}
+
+	for _, tc := range cases {
+		if owner := isProjectOwner(owners, tc.Email); owner != tc.Owner {
+			t.Fatalf("want isProjectOwner(%q)=%t, got %t", tc.Email, tc.Owner, owner)
+		}
+	}
+}
+
 func testAccCheckKubermaticProjectDestroy(s *terraform.State) error {
 	k := testAccProvider.Meta().(*kubermaticProviderMeta)
 	p := project.NewGetProjectParams()
@@ -169,3 +210,34 @@ func testAccCheckKubermaticProjectAttributes(rec *models.Project, name string, l
 		return nil
 	}
 }
+
+func TestProjectReadIdentifiers(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/projects/abcdef" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id":"abcdef","name":"test","status":"Active"}`))
+	}))
+	defer s.Close()
+
+	client, err := newClient(s.URL, &tokenAuth{token: "token"}, nil, defaultRequestTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &kubermaticProviderMeta{client: client, auth: &tokenAuth{token: "token"}, log: zap.NewNop().Sugar()}
+
+	r := resourceProject()
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name": "test",
+	})
+	d.SetId("abcdef")
+
+	if err := r.Read(d, k); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.Get("project_id").(string); got != "abcdef" {
+		t.Fatalf("want project_id %q, got %q", "abcdef", got)
+	}
+}
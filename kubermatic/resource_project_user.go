@@ -0,0 +1,188 @@
+package kubermatic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/users"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+// projectGroups are groups project members can be bound to
+var projectGroups = []string{"owners", "editors", "viewers"}
+
+func resourceProjectUser() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProjectUserCreate,
+		Read:   resourceProjectUserRead,
+		Update: resourceProjectUserUpdate,
+		Delete: resourceProjectUserDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceProjectUserImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Reference project identifier, provider default_project_id if not set",
+			},
+			"email": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Email of the user",
+			},
+			"group": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(projectGroups, false),
+				Description:  "Project group of the user, one of owners, editors or viewers",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Name of the user",
+			},
+		},
+	}
+}
+
+func resourceProjectUserCreate(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID, err := getProjectID(d, k)
+	if err != nil {
+		return err
+	}
+
+	p := users.NewAddUserToProjectParams()
+	p.SetProjectID(pID)
+	p.SetBody(newProjectUser("", d.Get("email").(string), pID, d.Get("group").(string)))
+
+	r, err := k.client.Users.AddUserToProject(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to add user '%s' to project '%s': %s", d.Get("email"), pID, getErrorResponse(err))
+	}
+
+	d.SetId(r.Payload.ID)
+	return resourceProjectUserRead(d, m)
+}
+
+func resourceProjectUserRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID := d.Get("project_id").(string)
+
+	p := users.NewGetUsersForProjectParams()
+	p.SetProjectID(pID)
+	r, err := k.client.Users.GetUsersForProject(p, k.auth)
+	if err != nil {
+		if _, ok := err.(*users.GetUsersForProjectForbidden); ok {
+			k.log.Infof("removing project user '%s' from terraform state file, access to project '%s' forbidden", d.Id(), pID)
+			d.SetId("")
+			return nil
+		}
+		if e, ok := err.(*users.GetUsersForProjectDefault); ok && e.Code() == http.StatusNotFound {
+			k.log.Infof("removing project user '%s' from terraform state file, could not find project '%s'", d.Id(), pID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to list users of project '%s': %s", pID, getErrorResponse(err))
+	}
+
+	var user *models.User
+	for _, u := range r.Payload {
+		if u.ID == d.Id() {
+			user = u
+			break
+		}
+	}
+	group := projectUserGroup(user, pID)
+	if group == "" {
+		k.log.Infof("removing project user '%s' from terraform state file, user is not a member of project '%s'", d.Id(), pID)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("email", user.Email)
+	d.Set("name", user.Name)
+	d.Set("group", group)
+	return nil
+}
+
+func resourceProjectUserUpdate(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID := d.Get("project_id").(string)
+
+	p := users.NewEditUserInProjectParams()
+	p.SetProjectID(pID)
+	p.SetUserID(d.Id())
+	p.SetBody(newProjectUser(d.Id(), d.Get("email").(string), pID, d.Get("group").(string)))
+
+	if _, err := k.client.Users.EditUserInProject(p, k.auth); err != nil {
+		return fmt.Errorf("unable to update user '%s' in project '%s': %s", d.Id(), pID, getErrorResponse(err))
+	}
+
+	return resourceProjectUserRead(d, m)
+}
+
+func resourceProjectUserDelete(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID := d.Get("project_id").(string)
+
+	p := users.NewDeleteUserFromProjectParams()
+	p.SetProjectID(pID)
+	p.SetUserID(d.Id())
+
+	if _, err := k.client.Users.DeleteUserFromProject(p, k.auth); err != nil {
+		if e, ok := err.(*users.DeleteUserFromProjectDefault); ok && e.Code() == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("unable to delete user '%s' from project '%s': %s", d.Id(), pID, getErrorResponse(err))
+	}
+	return nil
+}
+
+// resourceProjectUserImport imports project users by
+// <project_id>:<user_id> identifiers.
+func resourceProjectUserImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected project user identifier '%s', expected <project_id>:<user_id>", d.Id())
+	}
+	d.Set("project_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+func newProjectUser(id, email, projectID, group string) *models.User {
+	return &models.User{
+		ID:    id,
+		Email: email,
+		Projects: []*models.ProjectGroup{
+			{
+				ID:          projectID,
+				GroupPrefix: group,
+			},
+		},
+	}
+}
+
+// projectUserGroup returns group of the user in the project, empty if the
+// user is not a member.
+func projectUserGroup(user *models.User, projectID string) string {
+	if user == nil {
+		return ""
+	}
+	for _, p := range user.Projects {
+		if p != nil && p.ID == projectID {
+			return p.GroupPrefix
+		}
+	}
+	return ""
+}
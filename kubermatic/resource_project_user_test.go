@@ -0,0 +1,34 @@
+package kubermatic
+
+import (
+	"testing"
+
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestProjectUserGroup(t *testing.T) {
+	user := &models.User{
+		Email: "jane@example.com",
+		Projects: []*models.ProjectGroup{
+			{ID: "abcdef", GroupPrefix: "editors"},
+			{ID: "ghijkl", GroupPrefix: "owners"},
+		},
+	}
+
+	cases := []struct {
+		User      *models.User
+		ProjectID string
+		Expected  string
+	}{
+		{user, "ghijkl", "owners"},
+		{user, "abcdef", "editors"},
+		{user, "mnopqr", ""},
+		{nil, "abcdef", ""},
+	}
+
+	for _, tc := range cases {
+		if got := projectUserGroup(tc.User, tc.ProjectID); got != tc.Expected {
+			t.Fatalf("want group %q in project '%s', got %q", tc.Expected, tc.ProjectID, got)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package kubermatic
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/serviceaccounts"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+const (
+	serviceAccountActive   = "Active"
+	serviceAccountInactive = "Inactive"
+)
+
+func resourceServiceAccount() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceServiceAccountCreate,
+		Read:   resourceServiceAccountRead,
+		Update: resourceServiceAccountUpdate,
+		Delete: resourceServiceAccountDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceServiceAccountImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(defaultResourceTimeout),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Reference project identifier, provider default_project_id if not set",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Service account name",
+			},
+			"group": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"editors", "viewers"}, false),
+				Description:  "Project group of the service account, editors or viewers",
+			},
+			"service_account_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Service account identifier",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Service account status",
+			},
+			"creation_timestamp": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Creation timestamp",
+			},
+		},
+	}
+}
+
+func resourceServiceAccountCreate(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID, err := getProjectID(d, k)
+	if err != nil {
+		return err
+	}
+
+	p := serviceaccounts.NewAddServiceAccountToProjectParams()
+	p.SetProjectID(pID)
+	p.SetBody(&models.ServiceAccount{
+		Name:  d.Get("name").(string),
+		Group: d.Get("group").(string),
+	})
+
+	r, err := k.client.Serviceaccounts.AddServiceAccountToProject(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to create service account in project '%s': %s", pID, getErrorResponse(err))
+	}
+	d.SetId(r.Payload.ID)
+
+	id := r.Payload.ID
+	err = retry(k, getTimeout(d, k, schema.TimeoutCreate), func() *resource.RetryError {
+		sa, err := getServiceAccount(k, pID, id)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if sa == nil {
+			return resource.RetryableError(fmt.Errorf("service account '%s' is not available yet", id))
+		}
+		k.log.Debugf("creating service account '%s', currently in '%s' state", id, sa.Status)
+		switch sa.Status {
+		case serviceAccountActive:
+			return nil
+		case serviceAccountInactive:
+			return resource.RetryableError(fmt.Errorf("service account '%s' is in '%s' state", id, sa.Status))
+		}
+		return resource.NonRetryableError(fmt.Errorf("unexpected service account '%s' state '%s'", id, sa.Status))
+	})
+	if err != nil {
+		return fmt.Errorf("error while waiting for service account '%s' to be created: %s", id, err)
+	}
+	return resourceServiceAccountRead(d, m)
+}
+
+// getServiceAccount returns service account of the project, nil if it
+// doesn't exist.
+func getServiceAccount(k *kubermaticProviderMeta, projectID, id string) (*models.ServiceAccount, error) {
+	p := serviceaccounts.NewListServiceAccountsParams()
+	p.SetProjectID(projectID)
+	r, err := k.client.Serviceaccounts.ListServiceAccounts(p, k.auth)
+	if err != nil {
+		if e, ok := err.(*serviceaccounts.ListServiceAccountsDefault); ok && e.Code() == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("unable to list service accounts of project '%s': %s", projectID, getErrorResponse(err))
+	}
+	for _, sa := range r.Payload {
+		if sa.ID == id {
+			return sa, nil
+		}
+	}
+	return nil, nil
+}
+
+func resourceServiceAccountRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID := d.Get("project_id").(string)
+
+	sa, err := getServiceAccount(k, pID, d.Id())
+	if err != nil {
+		return err
+	}
+	if sa == nil {
+		k.log.Infof("removing service account '%s' from terraform state file, could not find the resource", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("service_account_id", sa.ID)
+	d.Set("name", sa.Name)
+	// API may return the group with the project suffix
+	d.Set("group", strings.TrimSuffix(sa.Group, "-"+pID))
+	d.Set("status", sa.Status)
+	d.Set("creation_timestamp", sa.CreationTimestamp.String())
+	return nil
+}
+
+func resourceServiceAccountUpdate(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID := d.Get("project_id").(string)
+
+	p := serviceaccounts.NewUpdateServiceAccountParams()
+	p.SetProjectID(pID)
+	p.SetServiceAccountID(d.Id())
+	p.SetBody(&models.ServiceAccount{
+		ID:    d.Id(),
+		Name:  d.Get("name").(string),
+		Group: d.Get("group").(string),
+	})
+
+	if _, err := k.client.Serviceaccounts.UpdateServiceAccount(p, k.auth); err != nil {
+		return fmt.Errorf("unable to update service account '%s': %s", d.Id(), getErrorResponse(err))
+	}
+	return resourceServiceAccountRead(d, m)
+}
+
+func resourceServiceAccountDelete(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+
+	p := serviceaccounts.NewDeleteServiceAccountParams()
+	p.SetProjectID(d.Get("project_id").(string))
+	p.SetServiceAccountID(d.Id())
+
+	if _, err := k.client.Serviceaccounts.DeleteServiceAccount(p, k.auth); err != nil {
+		if e, ok := err.(*serviceaccounts.DeleteServiceAccountDefault); ok && e.Code() == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("unable to delete service account '%s': %s", d.Id(), getErrorResponse(err))
+	}
+	return nil
+}
+
+// resourceServiceAccountImport imports service accounts by
+// <project_id>:<service_account_id> identifiers.
+func resourceServiceAccountImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected service account identifier '%s', expected <project_id>:<service_account_id>", d.Id())
+	}
+	d.Set("project_id", parts[0])
+	d.SetId(parts[1])
+	return []*schema.ResourceData{d}, nil
+}
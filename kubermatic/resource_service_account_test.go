@@ -0,0 +1,69 @@
+package kubermatic
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccKubermaticServiceAccount_Basic(t *testing.T) {
+	testName := randomTestName()
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckKubermaticServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccCheckKubermaticServiceAccountConfig, testName, testName, "viewers"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubermatic_service_account.acctest_sa", "name", testName),
+					resource.TestCheckResourceAttr("kubermatic_service_account.acctest_sa", "group", "viewers"),
+					resource.TestCheckResourceAttr("kubermatic_service_account.acctest_sa", "status", serviceAccountActive),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccCheckKubermaticServiceAccountConfig, testName, testName, "editors"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("kubermatic_service_account.acctest_sa", "group", "editors"),
+				),
+			},
+		},
+	})
+}
+
+const testAccCheckKubermaticServiceAccountConfig = `
+provider "kubermatic" {}
+
+resource "kubermatic_project" "acctest_project" {
+	name = "%s"
+}
+
+resource "kubermatic_service_account" "acctest_sa" {
+	project_id = kubermatic_project.acctest_project.id
+	name = "%s"
+	group = "%s"
+}
+`
+
+func testAccCheckKubermaticServiceAccountDestroy(s *terraform.State) error {
+	k := testAccProvider.Meta().(*kubermaticProviderMeta)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "kubermatic_service_account" {
+			continue
+		}
+
+		sa, err := getServiceAccount(k, rs.Primary.Attributes["project_id"], rs.Primary.ID)
+		if err != nil {
+			// project is gone together with its service accounts
+			continue
+		}
+		if sa != nil {
+			return fmt.Errorf("Service account still exists")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,192 @@
+package kubermatic
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/kubermatic/go-kubermatic/client/tokens"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func resourceServiceAccountToken() *schema.Resource {
+	return &schema.Resource{
+		Create:        resourceServiceAccountTokenCreate,
+		Read:          resourceServiceAccountTokenRead,
+		Update:        resourceServiceAccountTokenUpdate,
+		Delete:        resourceServiceAccountTokenDelete,
+		CustomizeDiff: resourceServiceAccountTokenCustomizeDiff,
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Reference project identifier, provider default_project_id if not set",
+			},
+			"service_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Reference service account identifier",
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Token name",
+			},
+			"rotate_when_expiring_within": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateDuration,
+				Description:  "Regenerate the token when it expires within the duration, e.g. 720h",
+			},
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token value, only available in the state of the Terraform run creating the token",
+			},
+			"token_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Token identifier",
+			},
+			"expiry": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Token expiry timestamp",
+			},
+			"rotation_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the token expires within rotate_when_expiring_within and is going to be regenerated",
+			},
+		},
+	}
+}
+
+func resourceServiceAccountTokenCreate(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	pID, err := getProjectID(d, k)
+	if err != nil {
+		return err
+	}
+	saID := d.Get("service_account_id").(string)
+
+	p := tokens.NewAddTokenToServiceAccountParams()
+	p.SetProjectID(pID)
+	p.SetServiceAccountID(saID)
+	p.SetBody(&models.ServiceAccountToken{
+		Name: d.Get("name").(string),
+	})
+
+	r, err := k.client.Tokens.AddTokenToServiceAccount(p, k.auth)
+	if err != nil {
+		return fmt.Errorf("unable to create token of service account '%s': %s", saID, getErrorResponse(err))
+	}
+
+	d.SetId(r.Payload.ID)
+	// the token is never returned again
+	d.Set("token", r.Payload.Token)
+	d.Set("rotation_required", false)
+	return resourceServiceAccountTokenRead(d, m)
+}
+
+func resourceServiceAccountTokenRead(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+	saID := d.Get("service_account_id").(string)
+
+	p := tokens.NewListServiceAccountTokensParams()
+	p.SetProjectID(d.Get("project_id").(string))
+	p.SetServiceAccountID(saID)
+	r, err := k.client.Tokens.ListServiceAccountTokens(p, k.auth)
+	if err != nil {
+		if e, ok := err.(*tokens.ListServiceAccountTokensDefault); ok && e.Code() == http.StatusNotFound {
+			k.log.Infof("removing token '%s' from terraform state file, could not find service account '%s'", d.Id(), saID)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("unable to list tokens of service account '%s': %s", saID, getErrorResponse(err))
+	}
+
+	var token *models.PublicServiceAccountToken
+	for _, t := range r.Payload {
+		if t.ID == d.Id() {
+			token = t
+			break
+		}
+	}
+	if token == nil {
+		k.log.Infof("removing token '%s' from terraform state file, could not find the resource", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("token_id", token.ID)
+	d.Set("name", token.Name)
+	d.Set("expiry", token.Expiry.String())
+	return nil
+}
+
+// resourceServiceAccountTokenUpdate only stores the new rotation window,
+// the token itself is not changed.
+func resourceServiceAccountTokenUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceServiceAccountTokenRead(d, m)
+}
+
+func resourceServiceAccountTokenDelete(d *schema.ResourceData, m interface{}) error {
+	k := m.(*kubermaticProviderMeta)
+
+	p := tokens.NewDeleteServiceAccountTokenParams()
+	p.SetProjectID(d.Get("project_id").(string))
+	p.SetServiceAccountID(d.Get("service_account_id").(string))
+	p.SetTokenID(d.Id())
+
+	if _, err := k.client.Tokens.DeleteServiceAccountToken(p, k.auth); err != nil {
+		if e, ok := err.(*tokens.DeleteServiceAccountTokenDefault); ok && e.Code() == http.StatusNotFound {
+			return nil
+		}
+		return fmt.Errorf("unable to delete token '%s': %s", d.Id(), getErrorResponse(err))
+	}
+	return nil
+}
+
+// resourceServiceAccountTokenCustomizeDiff replaces tokens expiring within
+// rotate_when_expiring_within.
+func resourceServiceAccountTokenCustomizeDiff(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	v, ok := d.GetOk("rotate_when_expiring_within")
+	if !ok {
+		return nil
+	}
+	// already validated
+	window, _ := time.ParseDuration(v.(string))
+
+	if tokenNeedsRotation(d.Get("expiry").(string), window, time.Now()) {
+		if err := d.SetNew("rotation_required", true); err != nil {
+			return err
+		}
+		return d.ForceNew("rotation_required")
+	}
+	return nil
+}
+
+// tokenNeedsRotation reports whether the token expires within the window,
+// unknown expiry never requires rotation.
+func tokenNeedsRotation(expiry string, window time.Duration, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, expiry)
+	// zero timestamps are returned if the expiry is not set
+	if err != nil || t.Unix() <= 0 {
+		return false
+	}
+	return t.Sub(now) < window
+}
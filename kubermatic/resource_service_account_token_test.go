@@ -0,0 +1,29 @@
+package kubermatic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+)
+
+func TestTokenNeedsRotation(t *testing.T) {
+	now := time.Date(2020, 6, 1, 12, 0, 0, 0, time.UTC)
+	cases := []struct {
+		Expiry   string
+		Window   time.Duration
+		Expected bool
+	}{
+		{strfmt.DateTime(now.Add(24 * time.Hour)).String(), 48 * time.Hour, true},
+		{strfmt.DateTime(now.Add(72 * time.Hour)).String(), 48 * time.Hour, false},
+		{strfmt.DateTime(now.Add(-time.Hour)).String(), time.Hour, true},
+		{strfmt.DateTime{}.String(), 48 * time.Hour, false},
+		{"", 48 * time.Hour, false},
+	}
+
+	for _, tc := range cases {
+		if got := tokenNeedsRotation(tc.Expiry, tc.Window, now); got != tc.Expected {
+			t.Fatalf("want tokenNeedsRotation(%q, %s)=%t, got %t", tc.Expiry, tc.Window, tc.Expected, got)
+		}
+	}
+}
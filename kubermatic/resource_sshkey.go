@@ -14,14 +14,17 @@ func resourceSSHKey() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceSSHKeyCreate,
 		Read:   resourceSSHKeyRead,
+		Update: resourceSSHKeyUpdate,
 		Delete: resourceSSHKeyDelete,
 
 		Schema: map[string]*schema.Schema{
 			"project_id": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ValidateFunc: validation.NoZeroValues,
 				ForceNew:     true,
+				Description:  "Reference project identifier, provider default_project_id if not set",
 			},
 			"name": {
 				Type:         schema.TypeString,
@@ -38,14 +41,50 @@ func resourceSSHKey() *schema.Resource {
 				},
 				ForceNew: true,
 			},
+			"sshkey_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "SSH key identifier",
+			},
+			"fingerprint": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fingerprint of the public key",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Adopt an existing SSH key with the same name instead of creating a new one, only used on creation. The public key of the existing SSH key has to match",
+			},
 		},
 	}
 }
 
 func resourceSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
+	pID, err := getProjectID(d, k)
+	if err != nil {
+		return err
+	}
+
+	if d.Get("adopt_existing").(bool) {
+		existing, err := findSSHKeyByName(k, pID, d.Get("name").(string))
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			if existing.Spec == nil || strings.TrimSpace(existing.Spec.PublicKey) != strings.TrimSpace(d.Get("public_key").(string)) {
+				return fmt.Errorf("unable to adopt SSH key '%s': public_key differs from the existing key", existing.ID)
+			}
+			k.log.Infof("adopting existing SSH key '%s'", existing.ID)
+			d.SetId(existing.ID)
+			return resourceSSHKeyRead(d, m)
+		}
+	}
+
 	p := project.NewCreateSSHKeyParams()
-	p.SetProjectID(d.Get("project_id").(string))
+	p.SetProjectID(pID)
 	p.Key = &models.SSHKey{
 		Name: d.Get("name").(string),
 		Spec: &models.SSHKeySpec{
@@ -60,6 +99,35 @@ func resourceSSHKeyCreate(d *schema.ResourceData, m interface{}) error {
 	return resourceSSHKeyRead(d, m)
 }
 
+func findSSHKeyByName(k *kubermaticProviderMeta, projectID, name string) (*models.SSHKey, error) {
+	p := project.NewListSSHKeysParams()
+	p.SetProjectID(projectID)
+	ret, err := k.client.Project.ListSSHKeys(p, k.auth)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list SSH keys: %s", getErrorResponse(err))
+	}
+
+	var found []*models.SSHKey
+	for _, r := range ret.Payload {
+		if r.Name == name {
+			found = append(found, r)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return nil, nil
+	case 1:
+		return found[0], nil
+	}
+
+	ids := make([]string, len(found))
+	for i, r := range found {
+		ids[i] = r.ID
+	}
+	return nil, fmt.Errorf("multiple SSH keys named '%s' found: %s", name, strings.Join(ids, ", "))
+}
+
 func resourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
 	p := project.NewListSSHKeysParams()
@@ -79,11 +147,18 @@ func resourceSSHKeyRead(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 		return nil
 	}
+	d.Set("sshkey_id", sshkey.ID)
 	d.Set("name", sshkey.Name)
 	d.Set("public_key", sshkey.Spec.PublicKey)
+	d.Set("fingerprint", sshkey.Spec.Fingerprint)
 	return nil
 }
 
+// resourceSSHKeyUpdate only stores adopt_existing, SSH keys are immutable.
+func resourceSSHKeyUpdate(d *schema.ResourceData, m interface{}) error {
+	return resourceSSHKeyRead(d, m)
+}
+
 func resourceSSHKeyDelete(d *schema.ResourceData, m interface{}) error {
 	k := m.(*kubermaticProviderMeta)
 	p := project.NewDeleteSSHKeyParams()
@@ -1,14 +1,18 @@
 package kubermatic
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/kubermatic/go-kubermatic/client/project"
 	"github.com/kubermatic/go-kubermatic/models"
+	"go.uber.org/zap"
 )
 
 func TestAccKubermaticSSHKey_Basic(t *testing.T) {
@@ -147,3 +151,136 @@ func testAccCheckKubermaticSSHKeyAttributes(rec *models.SSHKey, name string) res
 		return nil
 	}
 }
+
+func TestFindSSHKeyByName(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]*models.SSHKey{
+			{ID: "key-1", Name: "unique"},
+			{ID: "key-2", Name: "shared"},
+			{ID: "key-3", Name: "shared"},
+		})
+	}))
+	defer s.Close()
+
+	client, err := newClient(s.URL, &tokenAuth{token: "token"}, nil, defaultRequestTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &kubermaticProviderMeta{client: client, auth: &tokenAuth{token: "token"}}
+
+	cases := []struct {
+		Name     string
+		Expected string
+		Err      string
+	}{
+		{"unique", "key-1", ""},
+		{"missing", "", ""},
+		{"shared", "", "multiple SSH keys named 'shared' found: key-2, key-3"},
+	}
+
+	for _, tc := range cases {
+		got, err := findSSHKeyByName(k, "project", tc.Name)
+		if tc.Err != "" {
+			if err == nil || err.Error() != tc.Err {
+				t.Fatalf("want error %q, got %v", tc.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		var id string
+		if got != nil {
+			id = got.ID
+		}
+		if id != tc.Expected {
+			t.Fatalf("want SSH key %q, got %q", tc.Expected, id)
+		}
+	}
+}
+
+func TestSSHKeyAdoptExisting(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]*models.SSHKey{
+			{ID: "key-1", Name: "existing", Spec: &models.SSHKeySpec{PublicKey: "ssh-rsa AAAA user@example.com"}},
+		})
+	}))
+	defer s.Close()
+
+	client, err := newClient(s.URL, &tokenAuth{token: "token"}, nil, defaultRequestTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &kubermaticProviderMeta{client: client, auth: &tokenAuth{token: "token"}, log: zap.NewNop().Sugar()}
+
+	cases := []struct {
+		PublicKey string
+		Err       string
+	}{
+		{"ssh-rsa AAAA user@example.com\n", ""},
+		{"ssh-rsa BBBB user@example.com", "unable to adopt SSH key 'key-1': public_key differs from the existing key"},
+	}
+
+	for _, tc := range cases {
+		d := schema.TestResourceDataRaw(t, resourceSSHKey().Schema, map[string]interface{}{
+			"project_id":     "project",
+			"name":           "existing",
+			"public_key":     tc.PublicKey,
+			"adopt_existing": true,
+		})
+		err := resourceSSHKeyCreate(d, k)
+		if tc.Err != "" {
+			if err == nil || err.Error() != tc.Err {
+				t.Fatalf("want error %q, got %v", tc.Err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d.Id() != "key-1" {
+			t.Fatalf("want adopted SSH key %q, got %q", "key-1", d.Id())
+		}
+	}
+}
+
+func TestSSHKeyReadIdentifiers(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]*models.SSHKey{
+			{ID: "key-1", Name: "test", Spec: &models.SSHKeySpec{PublicKey: "ssh-rsa AAAA user@example.com", Fingerprint: "12:f8:7e:78:61:b4:bf:e2:de:24:15:96:4e:d4:72:53"}},
+		})
+	}))
+	defer s.Close()
+
+	client, err := newClient(s.URL, &tokenAuth{token: "token"}, nil, defaultRequestTimeout)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k := &kubermaticProviderMeta{client: client, auth: &tokenAuth{token: "token"}, log: zap.NewNop().Sugar()}
+
+	d := schema.TestResourceDataRaw(t, resourceSSHKey().Schema, map[string]interface{}{
+		"project_id": "project",
+		"name":       "test",
+		"public_key": "ssh-rsa AAAA user@example.com",
+	})
+	d.SetId("key-1")
+
+	if err := resourceSSHKeyRead(d, k); err != nil {
+		t.Fatal(err)
+	}
+	for key, want := range map[string]string{
+		"sshkey_id":   "key-1",
+		"fingerprint": "12:f8:7e:78:61:b4:bf:e2:de:24:15:96:4e:d4:72:53",
+	} {
+		if got := d.Get(key).(string); got != want {
+			t.Fatalf("want %s %q, got %q", key, want, got)
+		}
+	}
+}
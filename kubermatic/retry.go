@@ -0,0 +1,131 @@
+package kubermatic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+const (
+	defaultRetryMinDelay = retryTimeout
+	defaultRetryMaxDelay = 10 * time.Second
+)
+
+// retry calls f until it succeeds, returns a non-retryable error, the
+// timeout passes or the provider's max retries are exhausted. Only retries of
+// API errors classified by classifyError count against max retries, polling
+// pending states is limited by the timeout. Waits between calls grow
+// exponentially with jitter.
+func retry(k *kubermaticProviderMeta, timeout time.Duration, f func() *resource.RetryError) error {
+	minDelay, maxDelay := k.retryMinDelay, k.retryMaxDelay
+	if minDelay <= 0 {
+		minDelay = defaultRetryMinDelay
+	}
+	if maxDelay < minDelay {
+		maxDelay = defaultRetryMaxDelay
+		if maxDelay < minDelay {
+			maxDelay = minDelay
+		}
+	}
+
+	deadline := time.Now().Add(timeout)
+	retries := 0
+	for attempt := 0; ; attempt++ {
+		rerr := f()
+		if rerr == nil {
+			return nil
+		}
+		if !rerr.Retryable {
+			return rerr.Err
+		}
+		var apiErr retryableAPIError
+		if errors.As(rerr.Err, &apiErr) {
+			if k.maxRetries > 0 && retries >= k.maxRetries {
+				return fmt.Errorf("giving up after %d retries: %v", k.maxRetries, rerr.Err)
+			}
+			retries++
+		}
+
+		wait := retryDelay(attempt, minDelay, maxDelay)
+		if time.Now().Add(wait).After(deadline) {
+			return fmt.Errorf("timeout while waiting (%s): %v", timeout, rerr.Err)
+		}
+		time.Sleep(wait)
+	}
+}
+
+// retryDelay returns exponential delay of the attempt capped at max, the
+// upper half of it is randomized.
+func retryDelay(attempt int, min, max time.Duration) time.Duration {
+	d := max
+	if attempt < 32 {
+		if exp := min << uint(attempt); exp > 0 && exp < max {
+			d = exp
+		}
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// retryRule marks API errors retryable by status code, error message
+// substring or both, zero fields match any error.
+type retryRule struct {
+	code    int
+	message string
+}
+
+// transientRetryRules apply to every resource, they match errors of
+// overloaded or restarting backends.
+var transientRetryRules = []retryRule{
+	{code: http.StatusTooManyRequests},
+	{code: http.StatusBadGateway},
+	{code: http.StatusServiceUnavailable},
+	{code: http.StatusGatewayTimeout},
+	{message: "failed calling webhook"},
+}
+
+func (r retryRule) matches(code int, message string) bool {
+	if r.code != 0 && r.code != code {
+		return false
+	}
+	return r.message == "" || strings.Contains(message, r.message)
+}
+
+// retryableAPIError marks retries caused by API errors.
+type retryableAPIError struct {
+	error
+}
+
+func (e retryableAPIError) Unwrap() error {
+	return e.error
+}
+
+// classifyError returns detail as retryable error if err matches one of
+// the rules or transientRetryRules, or timed out, and non-retryable
+// otherwise.
+func classifyError(err error, rules []retryRule, detail error) *resource.RetryError {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return resource.RetryableError(retryableAPIError{detail})
+	}
+
+	var code int
+	var coder interface{ Code() int }
+	if errors.As(err, &coder) {
+		code = coder.Code()
+	}
+	message := getErrorResponse(err)
+	for _, rs := range [][]retryRule{rules, transientRetryRules} {
+		for _, r := range rs {
+			if r.matches(code, message) {
+				return resource.RetryableError(retryableAPIError{detail})
+			}
+		}
+	}
+	return resource.NonRetryableError(detail)
+}
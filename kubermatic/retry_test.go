@@ -0,0 +1,130 @@
+package kubermatic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/kubermatic/go-kubermatic/client/project"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestRetryDelay(t *testing.T) {
+	cases := []struct {
+		Attempt      int
+		Lower, Upper time.Duration
+	}{
+		{0, 500 * time.Millisecond, time.Second},
+		{1, time.Second, 2 * time.Second},
+		{3, 4 * time.Second, 8 * time.Second},
+		{10, 5 * time.Second, 10 * time.Second},
+		{100, 5 * time.Second, 10 * time.Second},
+	}
+
+	for _, tc := range cases {
+		for i := 0; i < 10; i++ {
+			d := retryDelay(tc.Attempt, time.Second, 10*time.Second)
+			if d < tc.Lower || d > tc.Upper {
+				t.Fatalf("want attempt %d delay within [%s, %s], got %s", tc.Attempt, tc.Lower, tc.Upper, d)
+			}
+		}
+	}
+}
+
+func TestRetry(t *testing.T) {
+	k := &kubermaticProviderMeta{
+		maxRetries:    2,
+		retryMinDelay: time.Millisecond,
+		retryMaxDelay: time.Millisecond,
+	}
+
+	calls := 0
+	err := retry(k, time.Minute, func() *resource.RetryError {
+		calls++
+		return classifyError(project.NewPatchClusterDefault(http.StatusServiceUnavailable), nil, fmt.Errorf("unavailable"))
+	})
+	if err == nil || calls != 3 {
+		t.Fatalf("want error after 3 calls, got %d calls and error %v", calls, err)
+	}
+
+	// pending states are polled until the timeout, not counted as retries
+	calls = 0
+	err = retry(k, 100*time.Millisecond, func() *resource.RetryError {
+		calls++
+		if calls%2 == 0 {
+			return classifyError(project.NewPatchClusterDefault(http.StatusServiceUnavailable), nil, fmt.Errorf("unavailable"))
+		}
+		return resource.RetryableError(fmt.Errorf("not ready"))
+	})
+	if err == nil || calls != 6 {
+		t.Fatalf("want error after 6 calls, got %d calls and error %v", calls, err)
+	}
+
+	calls = 0
+	err = retry(k, 50*time.Millisecond, func() *resource.RetryError {
+		calls++
+		return resource.RetryableError(fmt.Errorf("not ready"))
+	})
+	if err == nil || !strings.HasPrefix(err.Error(), "timeout while waiting") || calls <= 3 {
+		t.Fatalf("want timeout after more than 3 calls, got %d calls and error %v", calls, err)
+	}
+
+	calls = 0
+	err = retry(k, time.Minute, func() *resource.RetryError {
+		calls++
+		if calls < 2 {
+			return resource.RetryableError(fmt.Errorf("not ready"))
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Fatalf("want success after 2 calls, got %d calls and error %v", calls, err)
+	}
+
+	err = retry(k, time.Minute, func() *resource.RetryError {
+		return resource.NonRetryableError(fmt.Errorf("failed"))
+	})
+	if err == nil || err.Error() != "failed" {
+		t.Fatalf("want non-retryable error returned, got %v", err)
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	withMessage := func(code int, message string) error {
+		e := project.NewGetClusterHealthDefault(code)
+		e.Payload = &models.ErrorResponse{Error: &models.ErrorDetails{Message: &message}}
+		return e
+	}
+	rules := []retryRule{
+		{code: http.StatusConflict},
+		{code: http.StatusInternalServerError, message: "still being provisioned"},
+	}
+
+	cases := []struct {
+		Err       error
+		Retryable bool
+	}{
+		{project.NewPatchClusterDefault(http.StatusConflict), true},
+		{project.NewPatchClusterDefault(http.StatusServiceUnavailable), true},
+		{project.NewPatchClusterDefault(http.StatusBadRequest), false},
+		{withMessage(http.StatusInternalServerError, "cluster is still being provisioned"), true},
+		{withMessage(http.StatusBadRequest, "cluster is still being provisioned"), false},
+		{withMessage(http.StatusInternalServerError, "failed calling webhook \"example\""), true},
+		{withMessage(http.StatusInternalServerError, "invalid spec"), false},
+		{fmt.Errorf("request: %w", context.DeadlineExceeded), true},
+		{fmt.Errorf("failed"), false},
+	}
+
+	for _, tc := range cases {
+		detail := fmt.Errorf("detail")
+		got := classifyError(tc.Err, rules, detail)
+		if got.Retryable != tc.Retryable || !errors.Is(got.Err, detail) {
+			t.Fatalf("want %v classified retryable=%t, got retryable=%t with error %v", tc.Err, tc.Retryable, got.Retryable, got.Err)
+		}
+	}
+}
@@ -43,6 +43,24 @@ func clusterSpecFields() map[string]*schema.Schema {
 							Schema: awsCloudSpecFields(),
 						},
 					},
+					"azure": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Azure cluster specification",
+						Elem: &schema.Resource{
+							Schema: azureCloudSpecFields(),
+						},
+					},
+					"gcp": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "GCP cluster specification",
+						Elem: &schema.Resource{
+							Schema: gcpCloudSpecFields(),
+						},
+					},
 					"openstack": {
 						Type:        schema.TypeList,
 						Optional:    true,
@@ -87,6 +105,47 @@ func clusterSpecFields() map[string]*schema.Schema {
 			Default:     false,
 			Description: "Whether to enable audit logging or not",
 		},
+		"pod_node_selector": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to enable the PodNodeSelector admission plugin, per namespace default selectors are not configurable in this API version",
+		},
+	}
+}
+
+func clusterEventFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Event type",
+		},
+		"message": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Event message",
+		},
+		"count": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Number of times the event occurred",
+		},
+		"last_timestamp": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Time of the last occurrence",
+		},
+		"involved_object_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of the object the event is about",
+		},
+		"involved_object_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the object the event is about",
+		},
 	}
 }
 
@@ -94,40 +153,147 @@ func awsCloudSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"access_key_id": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Sensitive:   true,
-			Description: "Access key identifier",
+			Description: "Access key identifier, required unless the credential preset is set",
 		},
 		"secret_access_key": {
 			Type:        schema.TypeString,
-			Required:    true,
+			Optional:    true,
 			Sensitive:   true,
-			Description: "Secret access key",
+			Description: "Secret access key, required unless the credential preset is set",
 		},
 		"vpc_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Virtual private cloud identifier",
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Virtual private cloud identifier, the default VPC is used if not set",
 		},
 		"security_group_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Security group identifier",
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Security group identifier, a security group is created if not set",
 		},
 		"route_table_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Route table identifier",
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Route table identifier, the main route table of the VPC is used if not set",
 		},
 		"instance_profile_name": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "Instance profile name",
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Instance profile name of the nodes, an instance profile is created if not set",
 		},
 		"role_arn": {
 			Type:        schema.TypeString,
 			Optional:    true,
-			Description: "The IAM role the control plane will use over assume-role",
+			Computed:    true,
+			ForceNew:    true,
+			Description: "The IAM role the control plane will use over assume-role, a role is created if not set",
+		},
+	}
+}
+
+func azureCloudSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"tenant_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Tenant identifier, required unless the credential preset is set",
+		},
+		"subscription_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Subscription identifier, required unless the credential preset is set",
+		},
+		"client_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Service principal client identifier, required unless the credential preset is set",
+		},
+		"client_secret": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Sensitive:   true,
+			Description: "Service principal client secret, required unless the credential preset is set",
+		},
+		"resource_group": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Resource group name, a resource group is created if not set",
+		},
+		"vnet": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Virtual network name, a virtual network is created if not set",
+		},
+		"subnet": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Subnet name, a subnet is created if not set",
+		},
+		"route_table": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Route table name, a route table is created if not set",
+		},
+		"security_group": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Network security group name, a security group is created if not set",
+		},
+		"availability_set": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Availability set name, an availability set is created if not set",
+		},
+	}
+}
+
+func gcpCloudSpecFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"service_account": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Sensitive:    true,
+			ValidateFunc: validation.StringIsJSON,
+			Description:  "Service account JSON key, required unless the credential preset is set",
+		},
+		"network": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Network name, the default network is used if not set",
+		},
+		"subnetwork": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Subnetwork path, the default subnetwork of the network is used if not set",
 		},
 	}
 }
@@ -143,14 +309,12 @@ func openstackCloudSpecFields() map[string]*schema.Schema {
 		"username": {
 			Type:         schema.TypeString,
 			Required:     true,
-			ForceNew:     true,
 			Sensitive:    true,
 			ValidateFunc: validation.NoZeroValues,
 		},
 		"password": {
 			Type:         schema.TypeString,
 			Required:     true,
-			ForceNew:     true,
 			Sensitive:    true,
 			ValidateFunc: validation.NoZeroValues,
 		},
@@ -159,5 +323,33 @@ func openstackCloudSpecFields() map[string]*schema.Schema {
 			Required: true,
 			ForceNew: true,
 		},
+		"network": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Existing internal network name, a network, subnet and router are created if not set",
+		},
+		"subnet_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Existing subnet identifier",
+		},
+		"router_id": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Existing router identifier",
+		},
+		"security_groups": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			ForceNew:    true,
+			Description: "Existing security group name, a security group is created if not set",
+		},
 	}
 }
@@ -3,8 +3,10 @@ package kubermatic
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
 func isLabelOrTagReserved(path string) bool {
@@ -20,6 +22,49 @@ func validateLabelOrTag(key string) error {
 	return nil
 }
 
+var (
+	labelNameRegexp   = regexp.MustCompile(`^([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9]$`)
+	labelPrefixRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`)
+)
+
+// validateLabels checks keys and values follow Kubernetes label syntax.
+func validateLabels(v interface{}, k string) (strings []string, errors []error) {
+	for key, value := range v.(map[string]interface{}) {
+		if err := validateLabelKey(key); err != nil {
+			errors = append(errors, fmt.Errorf("%s: invalid label key '%s': %v", k, key, err))
+		}
+		if err := validateLabelValue(value.(string)); err != nil {
+			errors = append(errors, fmt.Errorf("%s.%s: invalid label value '%s': %v", k, key, value, err))
+		}
+	}
+	return
+}
+
+func validateLabelKey(key string) error {
+	name := key
+	if i := strings.LastIndex(key, "/"); i >= 0 {
+		prefix := key[:i]
+		name = key[i+1:]
+		if len(prefix) > 253 || !labelPrefixRegexp.MatchString(prefix) {
+			return fmt.Errorf("prefix must be a DNS subdomain of at most 253 characters")
+		}
+	}
+	if len(name) > 63 || !labelNameRegexp.MatchString(name) {
+		return fmt.Errorf("name must be at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character")
+	}
+	return nil
+}
+
+func validateLabelValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	if len(value) > 63 || !labelNameRegexp.MatchString(value) {
+		return fmt.Errorf("value must be empty or at most 63 alphanumeric characters, '-', '_' or '.', starting and ending with an alphanumeric character")
+	}
+	return nil
+}
+
 func nodeDeploymentSpecFields() map[string]*schema.Schema {
 	return map[string]*schema.Schema{
 		"replicas": {
@@ -57,6 +102,15 @@ func nodeDeploymentSpecFields() map[string]*schema.Schema {
 										Schema: awsNodeFields(),
 									},
 								},
+								"openstack": {
+									Type:        schema.TypeList,
+									Optional:    true,
+									MaxItems:    1,
+									Description: "OpenStack node deployment specification",
+									Elem: &schema.Resource{
+										Schema: openstackNodeFields(),
+									},
+								},
 							},
 						},
 					},
@@ -121,7 +175,8 @@ func nodeDeploymentSpecFields() map[string]*schema.Schema {
 									errors = append(errors, err)
 								}
 							}
-							return
+							ws, es := validateLabels(v, k)
+							return append(strings, ws...), append(errors, es...)
 						},
 					},
 					"taints": {
@@ -214,3 +269,56 @@ func awsNodeFields() map[string]*schema.Schema {
 		},
 	}
 }
+
+func openstackNodeFields() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"flavor": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+			Description:  "Instance flavor name",
+		},
+		"image": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.NoZeroValues,
+			Description:  "Image name or identifier, names are resolved in the data center region. Default images of the data center are listed by the kubermatic_operating_systems data source",
+		},
+		"disk_size": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "Size of the root volume in GBs, the root disk is on ephemeral storage sized by the flavor if not set",
+		},
+		"availability_zone": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Availability zone of the instances, the data center default is used if not set",
+		},
+		"use_floating_ip": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Whether to assign floating IPs to the instances",
+		},
+		"tags": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Computed:    true,
+			Description: "Additional instance metadata",
+			Elem:        schema.TypeString,
+			DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+				return isLabelOrTagReserved(k)
+			},
+			ValidateFunc: func(v interface{}, k string) (strings []string, errors []error) {
+				l := v.(map[string]interface{})
+				for key := range l {
+					if err := validateLabelOrTag(key); err != nil {
+						errors = append(errors, err)
+					}
+				}
+				return
+			},
+		},
+	}
+}
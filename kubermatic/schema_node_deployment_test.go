@@ -0,0 +1,27 @@
+package kubermatic
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateLabels(t *testing.T) {
+	cases := []struct {
+		Labels map[string]interface{}
+		Errors int
+	}{
+		{map[string]interface{}{"app": "web", "example.com/team": "core-1", "empty": ""}, 0},
+		{map[string]interface{}{"-app": "web"}, 1},
+		{map[string]interface{}{"Example.com/team": "core"}, 1},
+		{map[string]interface{}{"app": "web server"}, 1},
+		{map[string]interface{}{"app": strings.Repeat("a", 64)}, 1},
+		{map[string]interface{}{"a/b/c": "_x_"}, 2},
+	}
+
+	for _, tc := range cases {
+		_, errs := validateLabels(tc.Labels, "labels")
+		if len(errs) != tc.Errors {
+			t.Fatalf("want %d errors for %v, got %v", tc.Errors, tc.Labels, errs)
+		}
+	}
+}
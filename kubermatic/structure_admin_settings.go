@@ -0,0 +1,69 @@
+package kubermatic
+
+import (
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+// flatteners
+
+func flattenCustomLinks(in models.CustomLinks) []interface{} {
+	if len(in) < 1 {
+		return []interface{}{}
+	}
+
+	att := make([]interface{}, len(in))
+
+	for i, v := range in {
+		m := make(map[string]interface{})
+
+		if v.Label != "" {
+			m["label"] = v.Label
+		}
+		if v.URL != "" {
+			m["url"] = v.URL
+		}
+		if v.Icon != "" {
+			m["icon"] = v.Icon
+		}
+		if v.Location != "" {
+			m["location"] = v.Location
+		}
+
+		att[i] = m
+	}
+
+	return att
+}
+
+// expanders
+
+func expandCustomLinks(p []interface{}) models.CustomLinks {
+	if len(p) < 1 {
+		return nil
+	}
+	var links models.CustomLinks
+	for _, elem := range p {
+		in := elem.(map[string]interface{})
+		obj := &models.CustomLink{}
+
+		if v, ok := in["label"]; ok {
+			obj.Label = v.(string)
+		}
+
+		if v, ok := in["url"]; ok {
+			obj.URL = v.(string)
+		}
+
+		if v, ok := in["icon"]; ok {
+			obj.Icon = v.(string)
+		}
+
+		if v, ok := in["location"]; ok {
+			obj.Location = v.(string)
+		}
+
+		links = append(links, obj)
+	}
+
+	return links
+}
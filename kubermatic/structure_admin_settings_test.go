@@ -0,0 +1,79 @@
+package kubermatic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestFlattenCustomLinks(t *testing.T) {
+	cases := []struct {
+		Input          models.CustomLinks
+		ExpectedOutput []interface{}
+	}{
+		{
+			models.CustomLinks{
+				{
+					Label:    "Twitter",
+					URL:      "https://www.twitter.com/kubermatic",
+					Location: "footer",
+				},
+			},
+			[]interface{}{
+				map[string]interface{}{
+					"label":    "Twitter",
+					"url":      "https://www.twitter.com/kubermatic",
+					"location": "footer",
+				},
+			},
+		},
+		{
+			nil,
+			[]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenCustomLinks(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestExpandCustomLinks(t *testing.T) {
+	cases := []struct {
+		Input          []interface{}
+		ExpectedOutput models.CustomLinks
+	}{
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"label":    "Twitter",
+					"url":      "https://www.twitter.com/kubermatic",
+					"icon":     "",
+					"location": "default",
+				},
+			},
+			models.CustomLinks{
+				{
+					Label:    "Twitter",
+					URL:      "https://www.twitter.com/kubermatic",
+					Location: "default",
+				},
+			},
+		},
+		{
+			[]interface{}{},
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		output := expandCustomLinks(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from expander: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
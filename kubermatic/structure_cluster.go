@@ -1,6 +1,10 @@
 package kubermatic
 
 import (
+	"encoding/base64"
+	"sort"
+	"time"
+
 	"github.com/kubermatic/go-kubermatic/models"
 )
 
@@ -26,6 +30,8 @@ func flattenClusterSpec(values clusterPreserveValues, in *models.ClusterSpec) []
 		att["audit_logging"] = in.AuditLogging.Enabled
 	}
 
+	att["pod_node_selector"] = in.UsePodNodeSelectorAdmissionPlugin
+
 	if in.Cloud != nil {
 		att["cloud"] = flattenClusterCloudSpec(values, in.Cloud)
 	}
@@ -78,7 +84,15 @@ func flattenClusterCloudSpec(values clusterPreserveValues, in *models.CloudSpec)
 	}
 
 	if in.Aws != nil {
-		att["aws"] = flattenAWSCloudSpec(in.Aws)
+		att["aws"] = flattenAWSCloudSpec(values, in.Aws)
+	}
+
+	if in.Azure != nil {
+		att["azure"] = flattenAzureCloudSpec(values, in.Azure)
+	}
+
+	if in.Gcp != nil {
+		att["gcp"] = flattenGCPCloudSpec(values, in.Gcp)
 	}
 
 	if in.Openstack != nil {
@@ -88,19 +102,19 @@ func flattenClusterCloudSpec(values clusterPreserveValues, in *models.CloudSpec)
 	return []interface{}{att}
 }
 
-func flattenAWSCloudSpec(in *models.AWSCloudSpec) []interface{} {
+func flattenAWSCloudSpec(values clusterPreserveValues, in *models.AWSCloudSpec) []interface{} {
 	if in == nil {
 		return []interface{}{}
 	}
 
 	att := make(map[string]interface{})
 
-	if in.AccessKeyID != "" {
-		att["access_key_id"] = in.AccessKeyID
+	if values.awsAccessKeyID != nil {
+		att["access_key_id"] = values.awsAccessKeyID
 	}
 
-	if in.SecretAccessKey != "" {
-		att["secret_access_key"] = in.SecretAccessKey
+	if values.awsSecretAccessKey != nil {
+		att["secret_access_key"] = values.awsSecretAccessKey
 	}
 
 	if in.VPCID != "" {
@@ -126,6 +140,78 @@ func flattenAWSCloudSpec(in *models.AWSCloudSpec) []interface{} {
 	return []interface{}{att}
 }
 
+func flattenAzureCloudSpec(values clusterPreserveValues, in *models.AzureCloudSpec) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+
+	if values.azureTenantID != nil {
+		att["tenant_id"] = values.azureTenantID
+	}
+
+	if values.azureSubscriptionID != nil {
+		att["subscription_id"] = values.azureSubscriptionID
+	}
+
+	if values.azureClientID != nil {
+		att["client_id"] = values.azureClientID
+	}
+
+	if values.azureClientSecret != nil {
+		att["client_secret"] = values.azureClientSecret
+	}
+
+	if in.ResourceGroup != "" {
+		att["resource_group"] = in.ResourceGroup
+	}
+
+	if in.VNetName != "" {
+		att["vnet"] = in.VNetName
+	}
+
+	if in.SubnetName != "" {
+		att["subnet"] = in.SubnetName
+	}
+
+	if in.RouteTableName != "" {
+		att["route_table"] = in.RouteTableName
+	}
+
+	if in.SecurityGroup != "" {
+		att["security_group"] = in.SecurityGroup
+	}
+
+	if in.AvailabilitySet != "" {
+		att["availability_set"] = in.AvailabilitySet
+	}
+
+	return []interface{}{att}
+}
+
+func flattenGCPCloudSpec(values clusterPreserveValues, in *models.GCPCloudSpec) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+
+	if values.gcpServiceAccount != nil {
+		att["service_account"] = values.gcpServiceAccount
+	}
+
+	if in.Network != "" {
+		att["network"] = in.Network
+	}
+
+	if in.Subnetwork != "" {
+		att["subnetwork"] = in.Subnetwork
+	}
+
+	return []interface{}{att}
+}
+
 func flattenOpenstackSpec(values clusterPreserveValues, in *models.OpenstackCloudSpec) []interface{} {
 	if in == nil {
 		return []interface{}{}
@@ -137,6 +223,22 @@ func flattenOpenstackSpec(values clusterPreserveValues, in *models.OpenstackClou
 		att["floating_ip_pool"] = in.FloatingIPPool
 	}
 
+	if in.Network != "" {
+		att["network"] = in.Network
+	}
+
+	if in.SubnetID != "" {
+		att["subnet_id"] = in.SubnetID
+	}
+
+	if in.RouterID != "" {
+		att["router_id"] = in.RouterID
+	}
+
+	if in.SecurityGroups != "" {
+		att["security_groups"] = in.SecurityGroups
+	}
+
 	if values.openstackTenant != nil {
 		att["tenant"] = values.openstackTenant
 	}
@@ -150,6 +252,80 @@ func flattenOpenstackSpec(values clusterPreserveValues, in *models.OpenstackClou
 	return []interface{}{att}
 }
 
+// flattenCredentialsReference flattens the credentials secret reference of
+// the configured cloud provider.
+func flattenCredentialsReference(in *models.CloudSpec) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	var ref models.GlobalSecretKeySelector
+	switch {
+	case in.Aws != nil:
+		ref = in.Aws.CredentialsReference
+	case in.Azure != nil:
+		ref = in.Azure.CredentialsReference
+	case in.Gcp != nil:
+		ref = in.Gcp.CredentialsReference
+	case in.Openstack != nil:
+		ref = in.Openstack.CredentialsReference
+	}
+
+	if ref.Name == "" {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"name":      ref.Name,
+			"namespace": ref.Namespace,
+		},
+	}
+}
+
+// flattenClusterEvents flattens at most limit events, most recent first.
+func flattenClusterEvents(in []*models.Event, limit int) []interface{} {
+	if len(in) < 1 {
+		return []interface{}{}
+	}
+
+	events := make([]*models.Event, len(in))
+	copy(events, in)
+	sort.SliceStable(events, func(i, j int) bool {
+		return time.Time(events[i].LastTimestamp).After(time.Time(events[j].LastTimestamp))
+	})
+	if len(events) > limit {
+		events = events[:limit]
+	}
+
+	att := make([]interface{}, len(events))
+
+	for i, v := range events {
+		m := make(map[string]interface{})
+
+		if v.Type != "" {
+			m["type"] = v.Type
+		}
+		if v.Message != "" {
+			m["message"] = v.Message
+		}
+		m["count"] = int(v.Count)
+		m["last_timestamp"] = v.LastTimestamp.String()
+		if v.InvolvedObject != nil {
+			if v.InvolvedObject.Type != "" {
+				m["involved_object_type"] = v.InvolvedObject.Type
+			}
+			if v.InvolvedObject.Name != "" {
+				m["involved_object_name"] = v.InvolvedObject.Name
+			}
+		}
+
+		att[i] = m
+	}
+
+	return att
+}
+
 // expanders
 
 func expandClusterSpec(p []interface{}) *models.ClusterSpec {
@@ -174,6 +350,10 @@ func expandClusterSpec(p []interface{}) *models.ClusterSpec {
 		obj.AuditLogging = expandAuditLogging(v.(bool))
 	}
 
+	if v, ok := in["pod_node_selector"]; ok {
+		obj.UsePodNodeSelectorAdmissionPlugin = v.(bool)
+	}
+
 	if v, ok := in["cloud"]; ok {
 		obj.Cloud = expandClusterCloudSpec(v.([]interface{}))
 	}
@@ -238,6 +418,14 @@ func expandClusterCloudSpec(p []interface{}) *models.CloudSpec {
 		obj.Aws = expandAWSCloudSpec(v.([]interface{}))
 	}
 
+	if v, ok := in["azure"]; ok {
+		obj.Azure = expandAzureCloudSpec(v.([]interface{}))
+	}
+
+	if v, ok := in["gcp"]; ok {
+		obj.Gcp = expandGCPCloudSpec(v.([]interface{}))
+	}
+
 	if v, ok := in["openstack"]; ok {
 		obj.Openstack = expandOpenstackCloudSpec(v.([]interface{}))
 	}
@@ -294,6 +482,93 @@ func expandAWSCloudSpec(p []interface{}) *models.AWSCloudSpec {
 	return obj
 }
 
+func expandAzureCloudSpec(p []interface{}) *models.AzureCloudSpec {
+	if len(p) < 1 {
+		return nil
+	}
+	obj := &models.AzureCloudSpec{}
+	if p[0] == nil {
+		return obj
+	}
+	in := p[0].(map[string]interface{})
+
+	if v, ok := in["tenant_id"]; ok {
+		obj.TenantID = v.(string)
+	}
+
+	if v, ok := in["subscription_id"]; ok {
+		obj.SubscriptionID = v.(string)
+	}
+
+	if v, ok := in["client_id"]; ok {
+		obj.ClientID = v.(string)
+	}
+
+	if v, ok := in["client_secret"]; ok {
+		obj.ClientSecret = v.(string)
+	}
+
+	if v, ok := in["resource_group"]; ok {
+		obj.ResourceGroup = v.(string)
+	}
+
+	if v, ok := in["vnet"]; ok {
+		obj.VNetName = v.(string)
+	}
+
+	if v, ok := in["subnet"]; ok {
+		obj.SubnetName = v.(string)
+	}
+
+	if v, ok := in["route_table"]; ok {
+		obj.RouteTableName = v.(string)
+	}
+
+	if v, ok := in["security_group"]; ok {
+		obj.SecurityGroup = v.(string)
+	}
+
+	if v, ok := in["availability_set"]; ok {
+		obj.AvailabilitySet = v.(string)
+	}
+
+	return obj
+}
+
+func expandGCPCloudSpec(p []interface{}) *models.GCPCloudSpec {
+	if len(p) < 1 {
+		return nil
+	}
+	obj := &models.GCPCloudSpec{}
+	if p[0] == nil {
+		return obj
+	}
+	in := p[0].(map[string]interface{})
+
+	if v, ok := in["service_account"]; ok {
+		obj.ServiceAccount = encodeGCPServiceAccount(v.(string))
+	}
+
+	if v, ok := in["network"]; ok {
+		obj.Network = v.(string)
+	}
+
+	if v, ok := in["subnetwork"]; ok {
+		obj.Subnetwork = v.(string)
+	}
+
+	return obj
+}
+
+// encodeGCPServiceAccount returns the service account key base64 encoded,
+// as expected by the API.
+func encodeGCPServiceAccount(key string) string {
+	if key == "" {
+		return ""
+	}
+	return base64.StdEncoding.EncodeToString([]byte(key))
+}
+
 func expandOpenstackCloudSpec(p []interface{}) *models.OpenstackCloudSpec {
 	if len(p) < 1 {
 		return nil
@@ -312,6 +587,22 @@ func expandOpenstackCloudSpec(p []interface{}) *models.OpenstackCloudSpec {
 		obj.FloatingIPPool = v.(string)
 	}
 
+	if v, ok := in["network"]; ok {
+		obj.Network = v.(string)
+	}
+
+	if v, ok := in["subnet_id"]; ok {
+		obj.SubnetID = v.(string)
+	}
+
+	if v, ok := in["router_id"]; ok {
+		obj.RouterID = v.(string)
+	}
+
+	if v, ok := in["security_groups"]; ok {
+		obj.SecurityGroups = v.(string)
+	}
+
 	if v, ok := in["username"]; ok {
 		obj.Username = v.(string)
 	}
@@ -325,3 +616,24 @@ func expandOpenstackCloudSpec(p []interface{}) *models.OpenstackCloudSpec {
 
 	return obj
 }
+
+func expandClusterNodeDeployment(p []interface{}) *models.NodeDeployment {
+	if len(p) < 1 {
+		return nil
+	}
+	obj := &models.NodeDeployment{}
+	if p[0] == nil {
+		return obj
+	}
+	in := p[0].(map[string]interface{})
+
+	if v, ok := in["name"]; ok {
+		obj.Name = v.(string)
+	}
+
+	if v, ok := in["spec"]; ok {
+		obj.Spec = expandNodeDeploymentSpec(v.([]interface{}))
+	}
+
+	return obj
+}
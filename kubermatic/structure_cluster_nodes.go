@@ -0,0 +1,126 @@
+package kubermatic
+
+import (
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+// flatteners
+
+func flattenClusterNodes(in []*models.Node, metrics map[string]*models.NodeMetric) []interface{} {
+	if len(in) < 1 {
+		return []interface{}{}
+	}
+
+	att := make([]interface{}, len(in))
+
+	for i, v := range in {
+		m := make(map[string]interface{})
+
+		if v.ID != "" {
+			m["id"] = v.ID
+		}
+		if v.Name != "" {
+			m["name"] = v.Name
+		}
+
+		if v.Status != nil {
+			flattenNodeStatus(m, v.Status)
+		}
+
+		if nm, ok := metrics[v.Name]; ok {
+			m["metrics"] = flattenNodeMetric(nm)
+		}
+
+		att[i] = m
+	}
+
+	return att
+}
+
+func flattenNodeStatus(att map[string]interface{}, in *models.NodeStatus) {
+	if in.MachineName != "" {
+		att["machine_name"] = in.MachineName
+	}
+
+	if in.ErrorMessage != "" {
+		att["error_message"] = in.ErrorMessage
+	}
+
+	if in.NodeInfo != nil {
+		if in.NodeInfo.KubeletVersion != "" {
+			att["kubelet_version"] = in.NodeInfo.KubeletVersion
+		}
+		if in.NodeInfo.OperatingSystem != "" {
+			att["operating_system"] = in.NodeInfo.OperatingSystem
+		}
+		if in.NodeInfo.KernelVersion != "" {
+			att["kernel_version"] = in.NodeInfo.KernelVersion
+		}
+		if in.NodeInfo.Architecture != "" {
+			att["architecture"] = in.NodeInfo.Architecture
+		}
+		if in.NodeInfo.ContainerRuntime != "" {
+			att["container_runtime"] = in.NodeInfo.ContainerRuntime
+		}
+		if in.NodeInfo.ContainerRuntimeVersion != "" {
+			att["container_runtime_version"] = in.NodeInfo.ContainerRuntimeVersion
+		}
+	}
+
+	var addrs []interface{}
+	for _, a := range in.Addresses {
+		if a == nil {
+			continue
+		}
+		addrs = append(addrs, map[string]interface{}{
+			"type":    a.Type,
+			"address": a.Address,
+		})
+	}
+	if len(addrs) > 0 {
+		att["addresses"] = addrs
+	}
+
+	if in.Allocatable != nil {
+		att["allocatable"] = flattenNodeResources(in.Allocatable)
+	}
+
+	if in.Capacity != nil {
+		att["capacity"] = flattenNodeResources(in.Capacity)
+	}
+}
+
+func flattenNodeResources(in *models.NodeResources) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+
+	if in.CPU != "" {
+		att["cpu"] = in.CPU
+	}
+
+	if in.Memory != "" {
+		att["memory"] = in.Memory
+	}
+
+	return []interface{}{att}
+}
+
+func flattenNodeMetric(in *models.NodeMetric) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	return []interface{}{
+		map[string]interface{}{
+			"cpu_total_millicores":     int(in.CPUTotalMillicores),
+			"cpu_available_millicores": int(in.CPUAvailableMillicores),
+			"cpu_used_percentage":      int(in.CPUUsedPercentage),
+			"memory_total_bytes":       int(in.MemoryTotalBytes),
+			"memory_available_bytes":   int(in.MemoryAvailableBytes),
+			"memory_used_percentage":   int(in.MemoryUsedPercentage),
+		},
+	}
+}
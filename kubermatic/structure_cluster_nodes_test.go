@@ -0,0 +1,166 @@
+package kubermatic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/kubermatic/go-kubermatic/models"
+)
+
+func TestFlattenClusterNodes(t *testing.T) {
+	cases := []struct {
+		Input          []*models.Node
+		Metrics        map[string]*models.NodeMetric
+		ExpectedOutput []interface{}
+	}{
+		{
+			[]*models.Node{
+				{
+					ID:   "node-1",
+					Name: "node-1",
+					Status: &models.NodeStatus{
+						MachineName: "machine-1",
+						Addresses: []*models.NodeAddress{
+							{Type: "InternalIP", Address: "192.168.1.10"},
+						},
+						Allocatable: &models.NodeResources{CPU: "2", Memory: "3Gi"},
+						NodeInfo: &models.NodeSystemInfo{
+							KubeletVersion:  "v1.17.4",
+							OperatingSystem: "linux",
+						},
+					},
+				},
+			},
+			map[string]*models.NodeMetric{
+				"node-1": {
+					Name:               "node-1",
+					CPUTotalMillicores: 2000,
+					MemoryTotalBytes:   3221225472,
+				},
+			},
+			[]interface{}{
+				map[string]interface{}{
+					"id":               "node-1",
+					"name":             "node-1",
+					"machine_name":     "machine-1",
+					"kubelet_version":  "v1.17.4",
+					"operating_system": "linux",
+					"addresses": []interface{}{
+						map[string]interface{}{
+							"type":    "InternalIP",
+							"address": "192.168.1.10",
+						},
+					},
+					"allocatable": []interface{}{
+						map[string]interface{}{
+							"cpu":    "2",
+							"memory": "3Gi",
+						},
+					},
+					"metrics": []interface{}{
+						map[string]interface{}{
+							"cpu_total_millicores":     2000,
+							"cpu_available_millicores": 0,
+							"cpu_used_percentage":      0,
+							"memory_total_bytes":       3221225472,
+							"memory_available_bytes":   0,
+							"memory_used_percentage":   0,
+						},
+					},
+				},
+			},
+		},
+		{
+			[]*models.Node{{}},
+			nil,
+			[]interface{}{
+				map[string]interface{}{},
+			},
+		},
+		{
+			nil,
+			nil,
+			[]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenClusterNodes(tc.Input, tc.Metrics)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestFlattenNodeStatus(t *testing.T) {
+	cases := []struct {
+		Input          *models.NodeStatus
+		ExpectedOutput map[string]interface{}
+	}{
+		{
+			&models.NodeStatus{
+				Addresses: []*models.NodeAddress{
+					{Type: "InternalIP", Address: "192.168.1.10"},
+					nil,
+					{Type: "ExternalIP", Address: "203.0.113.10"},
+					{Type: "Hostname", Address: "node-1"},
+				},
+				Allocatable: &models.NodeResources{CPU: "1900m", Memory: "2964Mi"},
+				Capacity:    &models.NodeResources{CPU: "2", Memory: "4Gi"},
+			},
+			map[string]interface{}{
+				"addresses": []interface{}{
+					map[string]interface{}{
+						"type":    "InternalIP",
+						"address": "192.168.1.10",
+					},
+					map[string]interface{}{
+						"type":    "ExternalIP",
+						"address": "203.0.113.10",
+					},
+					map[string]interface{}{
+						"type":    "Hostname",
+						"address": "node-1",
+					},
+				},
+				"allocatable": []interface{}{
+					map[string]interface{}{
+						"cpu":    "1900m",
+						"memory": "2964Mi",
+					},
+				},
+				"capacity": []interface{}{
+					map[string]interface{}{
+						"cpu":    "2",
+						"memory": "4Gi",
+					},
+				},
+			},
+		},
+		{
+			&models.NodeStatus{
+				Addresses:   []*models.NodeAddress{nil},
+				Allocatable: &models.NodeResources{CPU: "500m"},
+			},
+			map[string]interface{}{
+				"allocatable": []interface{}{
+					map[string]interface{}{
+						"cpu": "500m",
+					},
+				},
+			},
+		},
+		{
+			&models.NodeStatus{},
+			map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := make(map[string]interface{})
+		flattenNodeStatus(output, tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -23,6 +23,21 @@ func flattenNodeDeploymentSpec(in *models.NodeDeploymentSpec) []interface{} {
 	return []interface{}{att}
 }
 
+// flattenNodeDeploymentStatus returns the computed replica counts, nothing
+// is set if the node deployment has no status yet.
+func flattenNodeDeploymentStatus(in *models.MachineDeploymentStatus) map[string]interface{} {
+	if in == nil {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"ready_replicas":       in.ReadyReplicas,
+		"available_replicas":   in.AvailableReplicas,
+		"unavailable_replicas": in.UnavailableReplicas,
+		"updated_replicas":     in.UpdatedReplicas,
+	}
+}
+
 func flattenNodeSpec(in *models.NodeSpec) []interface{} {
 	if in == nil {
 		return []interface{}{}
@@ -166,6 +181,10 @@ func flattenNodeCloudSpec(in *models.NodeCloudSpec) []interface{} {
 		att["aws"] = flattenAWSNodeSpec(in.Aws)
 	}
 
+	if in.Openstack != nil {
+		att["openstack"] = flattenOpenstackNodeSpec(in.Openstack)
+	}
+
 	// TODO: add all cloud providers
 
 	return []interface{}{att}
@@ -215,6 +234,42 @@ func flattenAWSNodeSpec(in *models.AWSNodeSpec) []interface{} {
 	return []interface{}{att}
 }
 
+func flattenOpenstackNodeSpec(in *models.OpenstackNodeSpec) []interface{} {
+	if in == nil {
+		return []interface{}{}
+	}
+
+	att := make(map[string]interface{})
+
+	att["use_floating_ip"] = in.UseFloatingIP
+
+	if l := len(in.Tags); l > 0 {
+		t := make(map[string]string, l)
+		for key, val := range in.Tags {
+			t[key] = val
+		}
+		att["tags"] = t
+	}
+
+	if in.Flavor != nil {
+		att["flavor"] = *in.Flavor
+	}
+
+	if in.Image != nil {
+		att["image"] = *in.Image
+	}
+
+	if in.RootDiskSizeGB != 0 {
+		att["disk_size"] = in.RootDiskSizeGB
+	}
+
+	if in.AvailabilityZone != "" {
+		att["availability_zone"] = in.AvailabilityZone
+	}
+
+	return []interface{}{att}
+}
+
 // expanders
 
 func expandNodeDeploymentSpec(p []interface{}) *models.NodeDeploymentSpec {
@@ -402,6 +457,10 @@ func expandNodeCloudSpec(p []interface{}) *models.NodeCloudSpec {
 		obj.Aws = expandAWSNodeSpec(v.([]interface{}))
 	}
 
+	if v, ok := in["openstack"]; ok {
+		obj.Openstack = expandOpenstackNodeSpec(v.([]interface{}))
+	}
+
 	return obj
 }
 
@@ -452,3 +511,43 @@ func expandAWSNodeSpec(p []interface{}) *models.AWSNodeSpec {
 
 	return obj
 }
+
+func expandOpenstackNodeSpec(p []interface{}) *models.OpenstackNodeSpec {
+	if len(p) < 1 {
+		return nil
+	}
+	obj := &models.OpenstackNodeSpec{}
+	if p[0] == nil {
+		return obj
+	}
+	in := p[0].(map[string]interface{})
+
+	if v, ok := in["flavor"]; ok {
+		obj.Flavor = strToPtr(v.(string))
+	}
+
+	if v, ok := in["image"]; ok {
+		obj.Image = strToPtr(v.(string))
+	}
+
+	if v, ok := in["disk_size"]; ok {
+		obj.RootDiskSizeGB = int64(v.(int))
+	}
+
+	if v, ok := in["availability_zone"]; ok {
+		obj.AvailabilityZone = v.(string)
+	}
+
+	if v, ok := in["use_floating_ip"]; ok {
+		obj.UseFloatingIP = v.(bool)
+	}
+
+	if v, ok := in["tags"]; ok {
+		obj.Tags = make(map[string]string)
+		for key, val := range v.(map[string]interface{}) {
+			obj.Tags[key] = val.(string)
+		}
+	}
+
+	return obj
+}
@@ -44,6 +44,49 @@ func TestFlattenNodeDeploymentSpec(t *testing.T) {
 	}
 }
 
+func TestFlattenNodeDeploymentStatus(t *testing.T) {
+	cases := []struct {
+		Input          *models.MachineDeploymentStatus
+		ExpectedOutput map[string]interface{}
+	}{
+		{
+			&models.MachineDeploymentStatus{
+				Replicas:            3,
+				ReadyReplicas:       2,
+				AvailableReplicas:   2,
+				UnavailableReplicas: 1,
+				UpdatedReplicas:     3,
+			},
+			map[string]interface{}{
+				"ready_replicas":       int32(2),
+				"available_replicas":   int32(2),
+				"unavailable_replicas": int32(1),
+				"updated_replicas":     int32(3),
+			},
+		},
+		{
+			&models.MachineDeploymentStatus{},
+			map[string]interface{}{
+				"ready_replicas":       int32(0),
+				"available_replicas":   int32(0),
+				"unavailable_replicas": int32(0),
+				"updated_replicas":     int32(0),
+			},
+		},
+		{
+			nil,
+			map[string]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenNodeDeploymentStatus(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from flattener: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
 func TestFlattenNodeSpec(t *testing.T) {
 	cases := []struct {
 		Input          *models.NodeSpec
@@ -267,6 +310,57 @@ func TestFlattenAWSNodeSpec(t *testing.T) {
 	}
 }
 
+func TestFlattenOpenstackNodeSpec(t *testing.T) {
+	cases := []struct {
+		Input          *models.OpenstackNodeSpec
+		ExpectedOutput []interface{}
+	}{
+		{
+			&models.OpenstackNodeSpec{
+				AvailabilityZone: "nova",
+				Flavor:           strToPtr("m1.small"),
+				Image:            strToPtr("Ubuntu Bionic"),
+				RootDiskSizeGB:   25,
+				Tags: map[string]string{
+					"foo": "bar",
+				},
+				UseFloatingIP: true,
+			},
+			[]interface{}{
+				map[string]interface{}{
+					"availability_zone": "nova",
+					"flavor":            "m1.small",
+					"image":             "Ubuntu Bionic",
+					"disk_size":         int64(25),
+					"tags": map[string]string{
+						"foo": "bar",
+					},
+					"use_floating_ip": true,
+				},
+			},
+		},
+		{
+			&models.OpenstackNodeSpec{},
+			[]interface{}{
+				map[string]interface{}{
+					"use_floating_ip": false,
+				},
+			},
+		},
+		{
+			nil,
+			[]interface{}{},
+		},
+	}
+
+	for _, tc := range cases {
+		output := flattenOpenstackNodeSpec(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from expander: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
+
 func TestExpandNodeDeploymentSpec(t *testing.T) {
 	cases := []struct {
 		Input          []interface{}
@@ -528,3 +622,52 @@ func TestExpandAWSNodeSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestExpandOpenstackNodeSpec(t *testing.T) {
+	cases := []struct {
+		Input          []interface{}
+		ExpectedOutput *models.OpenstackNodeSpec
+	}{
+		{
+			[]interface{}{
+				map[string]interface{}{
+					"availability_zone": "nova",
+					"flavor":            "m1.small",
+					"image":             "Ubuntu Bionic",
+					"disk_size":         25,
+					"tags": map[string]interface{}{
+						"foo": "bar",
+					},
+					"use_floating_ip": true,
+				},
+			},
+			&models.OpenstackNodeSpec{
+				AvailabilityZone: "nova",
+				Flavor:           strToPtr("m1.small"),
+				Image:            strToPtr("Ubuntu Bionic"),
+				RootDiskSizeGB:   25,
+				Tags: map[string]string{
+					"foo": "bar",
+				},
+				UseFloatingIP: true,
+			},
+		},
+		{
+			[]interface{}{
+				map[string]interface{}{},
+			},
+			&models.OpenstackNodeSpec{},
+		},
+		{
+			[]interface{}{},
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		output := expandOpenstackNodeSpec(tc.Input)
+		if diff := cmp.Diff(tc.ExpectedOutput, output); diff != "" {
+			t.Fatalf("Unexpected output from expander: mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
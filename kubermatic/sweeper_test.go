@@ -15,19 +15,19 @@ func TestMain(m *testing.M) {
 
 func sharedConfigForRegion(_ string) (*kubermaticProviderMeta, error) {
 	host := os.Getenv("KUBERMATIC_HOST")
-	client, err := newClient(host)
-	if err != nil {
-		return nil, fmt.Errorf("create client %w", err)
-	}
 	token := os.Getenv("KUBERMATIC_TOKEN")
-	auth, err := newAuth(token, "")
+	auth, err := newAuth(token, "", "")
 	if err != nil {
 		return nil, fmt.Errorf("auth api %w", err)
 	}
+	client, err := newClient(host, auth, nil, defaultRequestTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("create client %w", err)
+	}
 	log := zap.NewNop().Sugar()
 	return &kubermaticProviderMeta{
-		client,
-		auth,
-		log,
+		client: client,
+		auth:   auth,
+		log:    log,
 	}, nil
 }
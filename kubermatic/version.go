@@ -0,0 +1,33 @@
+package kubermatic
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-sdk/httpclient"
+)
+
+// providerVersion is version of the provider, set at build time with
+// -ldflags "-X github.com/kubermatic/terraform-provider-kubermatic/kubermatic.providerVersion=<version>"
+var providerVersion = "dev"
+
+// userAgent identifies Terraform and the provider, suffix is appended if
+// set.
+func userAgent(terraformVersion, suffix string) string {
+	ua := httpclient.TerraformUserAgent(terraformVersion) + " terraform-provider-kubermatic/" + providerVersion
+	if suffix != "" {
+		ua += " " + suffix
+	}
+	return ua
+}
+
+// userAgentTransport sets User-Agent header of all requests.
+type userAgentTransport struct {
+	next      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.next.RoundTrip(req)
+}
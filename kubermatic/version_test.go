@@ -0,0 +1,31 @@
+package kubermatic
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestUserAgentTransport(t *testing.T) {
+	var got string
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.UserAgent()
+	}))
+	defer s.Close()
+
+	ua := userAgent("0.12.24", "platform-ci/1.0")
+	c := &http.Client{Transport: &userAgentTransport{next: http.DefaultTransport, userAgent: ua}}
+	resp, err := c.Get(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if got != ua {
+		t.Fatalf("want User-Agent %q, got %q", ua, got)
+	}
+	if !strings.HasPrefix(got, "HashiCorp Terraform/0.12.24 ") || !strings.HasSuffix(got, " terraform-provider-kubermatic/dev platform-ci/1.0") {
+		t.Fatalf("unexpected User-Agent %q", got)
+	}
+}